@@ -33,6 +33,28 @@ func NewBox2DEmpty() *Box2D {
 	}
 }
 
+// Area returns the area of b.
+func (b *Box2D) Area() float64 {
+	if b.IsEmpty() {
+		return 0
+	}
+	return b.Width() * b.Height()
+}
+
+// Center returns the center of b. If b is empty then both x and y are NaN.
+func (b *Box2D) Center() (x, y float64) {
+	if b.IsEmpty() {
+		return math.NaN(), math.NaN()
+	}
+	return (b.MinX + b.MaxX) / 2, (b.MinY + b.MaxY) / 2
+}
+
+// Clone returns a clone of b.
+func (b *Box2D) Clone() *Box2D {
+	clone := *b
+	return &clone
+}
+
 // Contains returns true if b contains other.
 func (b *Box2D) Contains(other *Box2D) bool {
 	if b.IsEmpty() || other.IsEmpty() {
@@ -51,11 +73,49 @@ func (b *Box2D) ContextGeom(context *Context) *Geom {
 	return context.NewGeomFromBounds(b.MinX, b.MinY, b.MaxX, b.MaxY)
 }
 
+// Distance returns the distance between the closest points of b and other,
+// which is zero if they intersect. If either b or other is empty then it
+// returns +Inf.
+func (b *Box2D) Distance(other *Box2D) float64 {
+	if b.IsEmpty() || other.IsEmpty() {
+		return math.Inf(1)
+	}
+	dx := max(0, other.MinX-b.MaxX, b.MinX-other.MaxX)
+	dy := max(0, other.MinY-b.MaxY, b.MinY-other.MaxY)
+	return math.Hypot(dx, dy)
+}
+
 // Equals returns true if b equals other.
 func (b *Box2D) Equals(other *Box2D) bool {
 	return b.MinX == other.MinX && b.MinY == other.MinY && b.MaxX == other.MaxX && b.MaxY == other.MaxY
 }
 
+// ExpandBy expands b in place by d in all directions and returns b. Negative
+// values of d shrink b.
+func (b *Box2D) ExpandBy(d float64) *Box2D {
+	if b.IsEmpty() {
+		return b
+	}
+	b.MinX -= d
+	b.MinY -= d
+	b.MaxX += d
+	b.MaxY += d
+	if b.IsEmpty() {
+		*b = *NewBox2DEmpty()
+	}
+	return b
+}
+
+// ExpandToInclude expands b in place to include the point at x, y and returns
+// b.
+func (b *Box2D) ExpandToInclude(x, y float64) *Box2D {
+	b.MinX = min(b.MinX, x)
+	b.MinY = min(b.MinY, y)
+	b.MaxX = max(b.MaxX, x)
+	b.MaxY = max(b.MaxY, y)
+	return b
+}
+
 // Geom returns b as a Geom.
 func (b *Box2D) Geom() *Geom {
 	return b.ContextGeom(DefaultContext)
@@ -71,6 +131,20 @@ func (b *Box2D) Height() float64 {
 	return b.MaxY - b.MinY
 }
 
+// Intersection returns the intersection of b and other.
+func (b *Box2D) Intersection(other *Box2D) *Box2D {
+	intersection := &Box2D{
+		MinX: max(b.MinX, other.MinX),
+		MinY: max(b.MinY, other.MinY),
+		MaxX: min(b.MaxX, other.MaxX),
+		MaxY: min(b.MaxY, other.MaxY),
+	}
+	if intersection.IsEmpty() {
+		return NewBox2DEmpty()
+	}
+	return intersection
+}
+
 // Intersects returns true if b intersects other.
 func (b *Box2D) Intersects(other *Box2D) bool {
 	return !(other.MinX > b.MaxX || other.MinY > b.MaxY || other.MaxX < b.MinX || other.MaxY < b.MinY)
@@ -85,6 +159,16 @@ func (b *Box2D) String() string {
 	return fmt.Sprintf("[%f %f %f %f]", b.MinX, b.MinY, b.MaxX, b.MaxY)
 }
 
+// Union returns the smallest bounds that contains both b and other.
+func (b *Box2D) Union(other *Box2D) *Box2D {
+	return &Box2D{
+		MinX: min(b.MinX, other.MinX),
+		MinY: min(b.MinY, other.MinY),
+		MaxX: max(b.MaxX, other.MaxX),
+		MaxY: max(b.MaxY, other.MaxY),
+	}
+}
+
 // Width returns the width of b.
 func (b *Box2D) Width() float64 {
 	return b.MaxX - b.MinX
@@ -1,6 +1,7 @@
 package geos_test
 
 import (
+	"math"
 	"testing"
 
 	"github.com/alecthomas/assert/v2"
@@ -56,3 +57,40 @@ func TestBox2DPoint(t *testing.T) {
 	assert.True(t, b.IsPoint())
 	assert.Equal(t, 0.0, b.Width())
 }
+
+func TestBox2DAlgebra(t *testing.T) {
+	b := geos.NewBox2D(0, 0, 2, 4)
+	assert.Equal(t, 8.0, b.Area())
+	x, y := b.Center()
+	assert.Equal(t, 1.0, x)
+	assert.Equal(t, 2.0, y)
+
+	other := geos.NewBox2D(1, 1, 3, 3)
+	assert.Equal(t, geos.NewBox2D(0, 0, 3, 4), b.Union(other))
+	assert.Equal(t, geos.NewBox2D(1, 1, 2, 3), b.Intersection(other))
+	assert.True(t, b.Intersection(geos.NewBox2D(5, 5, 6, 6)).IsEmpty())
+	assert.Equal(t, b, b.Union(geos.NewBox2DEmpty()))
+	assert.True(t, b.Intersection(geos.NewBox2DEmpty()).IsEmpty())
+
+	assert.Equal(t, 0.0, b.Distance(other))
+	assert.Equal(t, 3.0, b.Distance(geos.NewBox2D(5, 0, 6, 1)))
+	assert.Equal(t, 5.0, b.Distance(geos.NewBox2D(5, 8, 6, 9)))
+	assert.True(t, math.IsInf(b.Distance(geos.NewBox2DEmpty()), 1))
+
+	clone := b.Clone()
+	assert.Equal(t, geos.NewBox2D(-1, -1, 3, 5), clone.ExpandBy(1))
+	assert.Equal(t, geos.NewBox2D(0, 0, 2, 4), b)
+	assert.True(t, b.Clone().ExpandBy(-1.5).IsEmpty())
+	assert.Equal(t, geos.NewBox2D(0, -1, 5, 4), b.Clone().ExpandToInclude(5, -1))
+	assert.Equal(t, geos.NewBox2D(1, 2, 1, 2), geos.NewBox2DEmpty().ExpandToInclude(1, 2))
+}
+
+func TestBox2DEmptyAlgebra(t *testing.T) {
+	b := geos.NewBox2DEmpty()
+	assert.Equal(t, 0.0, b.Area())
+	x, y := b.Center()
+	assert.True(t, math.IsNaN(x))
+	assert.True(t, math.IsNaN(y))
+	assert.True(t, b.Clone().ExpandBy(1).IsEmpty())
+	assert.True(t, b.Union(geos.NewBox2DEmpty()).IsEmpty())
+}
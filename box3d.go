@@ -5,7 +5,9 @@ import (
 	"math"
 )
 
-// A Box3D is a three-dimensional bounds.
+// A Box3D is a three-dimensional bounds. A non-empty Box3D with a MinZ of +Inf
+// and a MaxZ of -Inf, as returned by Geom.Bounds3D for geometries without Z,
+// has no Z range and its Z is ignored.
 type Box3D struct {
 	MinX float64
 	MinY float64
@@ -39,6 +41,190 @@ func NewBox3DEmpty() *Box3D {
 	}
 }
 
+// Box2D returns the two-dimensional bounds of b, ignoring Z.
+func (b *Box3D) Box2D() *Box2D {
+	return NewBox2D(b.MinX, b.MinY, b.MaxX, b.MaxY)
+}
+
+// Center returns the center of b. If b is empty then x, y, and z are NaN. If b
+// has no Z range then z is NaN.
+func (b *Box3D) Center() (x, y, z float64) {
+	if b.IsEmpty() {
+		return math.NaN(), math.NaN(), math.NaN()
+	}
+	if !b.hasZ() {
+		return (b.MinX + b.MaxX) / 2, (b.MinY + b.MaxY) / 2, math.NaN()
+	}
+	return (b.MinX + b.MaxX) / 2, (b.MinY + b.MaxY) / 2, (b.MinZ + b.MaxZ) / 2
+}
+
+// Clone returns a clone of b.
+func (b *Box3D) Clone() *Box3D {
+	clone := *b
+	return &clone
+}
+
+// Contains returns true if b contains other. Z is ignored unless both b and
+// other have Z ranges.
+func (b *Box3D) Contains(other *Box3D) bool {
+	if b.IsEmpty() || other.IsEmpty() {
+		return false
+	}
+	if !(other.MinX >= b.MinX && other.MinY >= b.MinY && other.MaxX <= b.MaxX && other.MaxY <= b.MaxY) {
+		return false
+	}
+	return !b.hasZ() || !other.hasZ() || other.MinZ >= b.MinZ && other.MaxZ <= b.MaxZ
+}
+
+// ContainsPoint returns true if b contains the point at x, y, z. If b has no Z
+// range then z is ignored.
+func (b *Box3D) ContainsPoint(x, y, z float64) bool {
+	if !(b.MinX <= x && x <= b.MaxX && b.MinY <= y && y <= b.MaxY) {
+		return false
+	}
+	return !b.hasZ() || b.MinZ <= z && z <= b.MaxZ
+}
+
+// Depth returns the depth of b, which is zero if b has no Z range.
+func (b *Box3D) Depth() float64 {
+	if !b.hasZ() {
+		return 0
+	}
+	return b.MaxZ - b.MinZ
+}
+
+// Distance returns the distance between the closest points of b and other,
+// which is zero if they intersect. If either b or other is empty then it
+// returns +Inf.
+func (b *Box3D) Distance(other *Box3D) float64 {
+	if b.IsEmpty() || other.IsEmpty() {
+		return math.Inf(1)
+	}
+	dx := max(0, other.MinX-b.MaxX, b.MinX-other.MaxX)
+	dy := max(0, other.MinY-b.MaxY, b.MinY-other.MaxY)
+	dz := 0.0
+	if b.hasZ() && other.hasZ() {
+		dz = max(0, other.MinZ-b.MaxZ, b.MinZ-other.MaxZ)
+	}
+	return math.Sqrt(dx*dx + dy*dy + dz*dz)
+}
+
+// Equals returns true if b equals other.
+func (b *Box3D) Equals(other *Box3D) bool {
+	return b.MinX == other.MinX && b.MinY == other.MinY && b.MinZ == other.MinZ &&
+		b.MaxX == other.MaxX && b.MaxY == other.MaxY && b.MaxZ == other.MaxZ
+}
+
+// ExpandBy expands b in place by d in all directions and returns b. Negative
+// values of d shrink b.
+func (b *Box3D) ExpandBy(d float64) *Box3D {
+	if b.IsEmpty() {
+		return b
+	}
+	hasZ := b.hasZ()
+	b.MinX -= d
+	b.MinY -= d
+	b.MaxX += d
+	b.MaxY += d
+	if hasZ {
+		b.MinZ -= d
+		b.MaxZ += d
+	}
+	if b.IsEmpty() {
+		*b = *NewBox3DEmpty()
+	}
+	return b
+}
+
+// ExpandToInclude expands b in place to include the point at x, y, z and
+// returns b.
+func (b *Box3D) ExpandToInclude(x, y, z float64) *Box3D {
+	b.MinX = min(b.MinX, x)
+	b.MinY = min(b.MinY, y)
+	b.MinZ = min(b.MinZ, z)
+	b.MaxX = max(b.MaxX, x)
+	b.MaxY = max(b.MaxY, y)
+	b.MaxZ = max(b.MaxZ, z)
+	return b
+}
+
+// IsEmpty returns true if b is empty.
+func (b *Box3D) IsEmpty() bool {
+	return b.MinX > b.MaxX || b.MinY > b.MaxY || b.hasZ() && b.MinZ > b.MaxZ
+}
+
+// Height returns the height of b.
+func (b *Box3D) Height() float64 {
+	return b.MaxY - b.MinY
+}
+
+// Intersection returns the intersection of b and other. If only one of b and
+// other has a Z range then the intersection has that Z range.
+func (b *Box3D) Intersection(other *Box3D) *Box3D {
+	intersection := &Box3D{
+		MinX: max(b.MinX, other.MinX),
+		MinY: max(b.MinY, other.MinY),
+		MinZ: max(b.MinZ, other.MinZ),
+		MaxX: min(b.MaxX, other.MaxX),
+		MaxY: min(b.MaxY, other.MaxY),
+		MaxZ: min(b.MaxZ, other.MaxZ),
+	}
+	switch {
+	case !b.hasZ():
+		intersection.MinZ, intersection.MaxZ = other.MinZ, other.MaxZ
+	case !other.hasZ():
+		intersection.MinZ, intersection.MaxZ = b.MinZ, b.MaxZ
+	}
+	if intersection.IsEmpty() {
+		return NewBox3DEmpty()
+	}
+	return intersection
+}
+
+// Intersects returns true if b intersects other. Z is ignored unless both b and
+// other have Z ranges.
+func (b *Box3D) Intersects(other *Box3D) bool {
+	if other.MinX > b.MaxX || other.MinY > b.MaxY || other.MaxX < b.MinX || other.MaxY < b.MinY {
+		return false
+	}
+	return !b.hasZ() || !other.hasZ() || !(other.MinZ > b.MaxZ || other.MaxZ < b.MinZ)
+}
+
+// IsPoint returns true if b is a point.
+func (b *Box3D) IsPoint() bool {
+	return b.MinX == b.MaxX && b.MinY == b.MaxY && (!b.hasZ() || b.MinZ == b.MaxZ)
+}
+
 func (b *Box3D) String() string {
-	return fmt.Sprintf("[%f %f %f %f %f %f]", b.MinX, b.MinY, b.MinZ, b.MaxX, b.MaxY, b.MaxX)
+	return fmt.Sprintf("[%f %f %f %f %f %f]", b.MinX, b.MinY, b.MinZ, b.MaxX, b.MaxY, b.MaxZ)
+}
+
+// Union returns the smallest bounds that contains both b and other.
+func (b *Box3D) Union(other *Box3D) *Box3D {
+	return &Box3D{
+		MinX: min(b.MinX, other.MinX),
+		MinY: min(b.MinY, other.MinY),
+		MinZ: min(b.MinZ, other.MinZ),
+		MaxX: max(b.MaxX, other.MaxX),
+		MaxY: max(b.MaxY, other.MaxY),
+		MaxZ: max(b.MaxZ, other.MaxZ),
+	}
+}
+
+// Volume returns the volume of b, which is zero if b has no Z range.
+func (b *Box3D) Volume() float64 {
+	if b.IsEmpty() || !b.hasZ() {
+		return 0
+	}
+	return b.Width() * b.Height() * b.Depth()
+}
+
+// Width returns the width of b.
+func (b *Box3D) Width() float64 {
+	return b.MaxX - b.MinX
+}
+
+// hasZ returns true if b has a Z range.
+func (b *Box3D) hasZ() bool {
+	return !math.IsInf(b.MinZ, 1) || !math.IsInf(b.MaxZ, -1)
 }
@@ -0,0 +1,88 @@
+package geos_test
+
+import (
+	"math"
+	"runtime"
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+
+	"github.com/twpayne/go-geos"
+)
+
+func TestBox3D(t *testing.T) {
+	b := geos.NewBox3D(0, 0, 0, 2, 4, 6)
+	assert.Equal(t, "[0.000000 0.000000 0.000000 2.000000 4.000000 6.000000]", b.String())
+	assert.Equal(t, geos.NewBox2D(0, 0, 2, 4), b.Box2D())
+	assert.True(t, b.Contains(b))
+	assert.True(t, b.Contains(geos.NewBox3D(1, 1, 1, 2, 2, 2)))
+	assert.False(t, b.Contains(geos.NewBox3D(1, 1, 1, 2, 2, 7)))
+	assert.True(t, b.ContainsPoint(1, 2, 3))
+	assert.False(t, b.ContainsPoint(1, 2, 7))
+	assert.True(t, b.Equals(geos.NewBox3D(0, 0, 0, 2, 4, 6)))
+	assert.False(t, b.IsEmpty())
+	assert.False(t, b.IsPoint())
+	assert.Equal(t, 2.0, b.Width())
+	assert.Equal(t, 4.0, b.Height())
+	assert.Equal(t, 6.0, b.Depth())
+	assert.Equal(t, 48.0, b.Volume())
+	x, y, z := b.Center()
+	assert.Equal(t, 1.0, x)
+	assert.Equal(t, 2.0, y)
+	assert.Equal(t, 3.0, z)
+
+	other := geos.NewBox3D(1, 1, 1, 3, 3, 3)
+	assert.True(t, b.Intersects(other))
+	assert.False(t, b.Intersects(geos.NewBox3D(0, 0, 7, 1, 1, 8)))
+	assert.Equal(t, geos.NewBox3D(0, 0, 0, 3, 4, 6), b.Union(other))
+	assert.Equal(t, geos.NewBox3D(1, 1, 1, 2, 3, 3), b.Intersection(other))
+	assert.True(t, b.Intersection(geos.NewBox3D(0, 0, 7, 1, 1, 8)).IsEmpty())
+	assert.Equal(t, 0.0, b.Distance(other))
+	assert.Equal(t, 2.0, b.Distance(geos.NewBox3D(0, 0, 8, 1, 1, 9)))
+	assert.True(t, math.IsInf(b.Distance(geos.NewBox3DEmpty()), 1))
+
+	assert.Equal(t, geos.NewBox3D(-1, -1, -1, 3, 5, 7), b.Clone().ExpandBy(1))
+	assert.Equal(t, geos.NewBox3D(0, 0, 0, 2, 4, 6), b)
+	assert.Equal(t, geos.NewBox3D(0, 0, -1, 2, 4, 6), b.Clone().ExpandToInclude(1, 1, -1))
+}
+
+func TestBox3DEmpty(t *testing.T) {
+	b := geos.NewBox3DEmpty()
+	assert.True(t, b.IsEmpty())
+	assert.True(t, b.Box2D().IsEmpty())
+	assert.False(t, b.Contains(b))
+	assert.False(t, b.Intersects(b))
+	assert.Equal(t, 0.0, b.Volume())
+	assert.True(t, b.Clone().ExpandBy(1).IsEmpty())
+	assert.Equal(t, geos.NewBox3D(1, 2, 3, 1, 2, 3), b.Clone().ExpandToInclude(1, 2, 3))
+	assert.True(t, b.ExpandToInclude(1, 2, 3).IsPoint())
+}
+
+func TestBox3DNoZ(t *testing.T) {
+	defer runtime.GC() // Exercise finalizers.
+	c := geos.NewContext()
+	polygon := mustNewGeomFromWKT(t, c, "POLYGON ((0 0, 4 0, 4 4, 0 4, 0 0))")
+	b := polygon.Bounds3D()
+	assert.False(t, b.IsEmpty())
+	assert.Equal(t, 0.0, b.Depth())
+	assert.Equal(t, 0.0, b.Volume())
+	x, y, z := b.Center()
+	assert.Equal(t, 2.0, x)
+	assert.Equal(t, 2.0, y)
+	assert.True(t, math.IsNaN(z))
+
+	assert.True(t, b.Intersects(b))
+	assert.True(t, b.Contains(b))
+	assert.True(t, b.Intersects(mustNewGeomFromWKT(t, c, "POLYGON ((2 2, 6 2, 6 6, 2 6, 2 2))").Bounds3D()))
+	assert.False(t, b.Intersects(mustNewGeomFromWKT(t, c, "POLYGON ((5 5, 6 5, 6 6, 5 6, 5 5))").Bounds3D()))
+	assert.True(t, b.Contains(mustNewGeomFromWKT(t, c, "POINT (1 1)").Bounds3D()))
+	assert.False(t, b.Contains(mustNewGeomFromWKT(t, c, "POINT (5 5)").Bounds3D()))
+	assert.True(t, b.Intersects(geos.NewBox3D(1, 1, 10, 2, 2, 20)))
+	assert.True(t, b.Contains(geos.NewBox3D(1, 1, 10, 2, 2, 20)))
+	assert.True(t, b.ContainsPoint(1, 1, 100))
+	assert.Equal(t, geos.NewBox3D(1, 1, 10, 2, 2, 20), b.Intersection(geos.NewBox3D(1, 1, 10, 2, 2, 20)))
+	assert.Equal(t, 1.0, b.Distance(geos.NewBox3D(5, 0, 10, 6, 1, 20)))
+
+	expanded := b.Clone().ExpandBy(1)
+	assert.Equal(t, &geos.Box3D{MinX: -1, MinY: -1, MinZ: math.Inf(1), MaxX: 5, MaxY: 5, MaxZ: math.Inf(-1)}, expanded)
+}
@@ -3,14 +3,49 @@ package geos
 // #include "go-geos.h"
 import "C"
 
-import "runtime"
+import (
+	"fmt"
+	"runtime"
+)
+
+// Default buffer parameters, as used by GEOS.
+const (
+	DefaultBufEndCapStyle      = BufCapStyleRound
+	DefaultBufJoinStyle        = BufJoinStyleRound
+	DefaultBufMitreLimit       = 5.0
+	DefaultBufQuadrantSegments = 8
+)
 
 // A BufParams contains parameters for BufferWithParams.
 type BufParams struct {
 	context    *Context
 	cBufParams *C.struct_GEOSBufParams_t
+	value      BufParamsValue
+}
+
+// A BufParamsValue is a declarative description of buffer parameters, suitable
+// for serialization. Zero values are replaced with GEOS's defaults.
+type BufParamsValue struct {
+	EndCapStyle      BufCapStyle  `json:"endCapStyle,omitempty"`
+	JoinStyle        BufJoinStyle `json:"joinStyle,omitempty"`
+	MitreLimit       float64      `json:"mitreLimit,omitempty"`
+	QuadrantSegments int          `json:"quadrantSegments,omitempty"`
+	SingleSided      bool         `json:"singleSided,omitempty"`
 }
 
+var (
+	bufCapStyleStrings = map[BufCapStyle]string{
+		BufCapStyleRound:  "round",
+		BufCapStyleFlat:   "flat",
+		BufCapStyleSquare: "square",
+	}
+	bufJoinStyleStrings = map[BufJoinStyle]string{
+		BufJoinStyleRound: "round",
+		BufJoinStyleMitre: "mitre",
+		BufJoinStyleBevel: "bevel",
+	}
+)
+
 // NewBufParams returns a new BufParams.
 func (c *Context) NewBufParams() *BufParams {
 	c.mutex.Lock()
@@ -22,12 +57,63 @@ func (c *Context) NewBufParams() *BufParams {
 	bufParams := &BufParams{
 		context:    c,
 		cBufParams: cBufParams,
+		value: BufParamsValue{
+			EndCapStyle:      DefaultBufEndCapStyle,
+			JoinStyle:        DefaultBufJoinStyle,
+			MitreLimit:       DefaultBufMitreLimit,
+			QuadrantSegments: DefaultBufQuadrantSegments,
+		},
 	}
 	c.ref()
 	runtime.AddCleanup(bufParams, c.destroyBufParams, cBufParams)
 	return bufParams
 }
 
+// NewBufParamsFromValue returns a new BufParams with the parameters in value.
+func (c *Context) NewBufParamsFromValue(value BufParamsValue) *BufParams {
+	value = value.withDefaults()
+	return c.NewBufParams().
+		SetEndCapStyle(value.EndCapStyle).
+		SetJoinStyle(value.JoinStyle).
+		SetMitreLimit(value.MitreLimit).
+		SetQuadrantSegments(value.QuadrantSegments).
+		SetSingleSided(value.SingleSided)
+}
+
+// Clone returns a clone of p.
+func (p *BufParams) Clone() *BufParams {
+	return p.context.NewBufParamsFromValue(p.Value())
+}
+
+// EndCapStyle returns p's end cap style.
+func (p *BufParams) EndCapStyle() BufCapStyle {
+	p.context.mutex.Lock()
+	defer p.context.mutex.Unlock()
+	return p.value.EndCapStyle
+}
+
+// JoinStyle returns p's join style.
+func (p *BufParams) JoinStyle() BufJoinStyle {
+	p.context.mutex.Lock()
+	defer p.context.mutex.Unlock()
+	return p.value.JoinStyle
+}
+
+// MitreLimit returns p's mitre limit.
+func (p *BufParams) MitreLimit() float64 {
+	p.context.mutex.Lock()
+	defer p.context.mutex.Unlock()
+	return p.value.MitreLimit
+}
+
+// QuadrantSegments returns the number of segments used to stroke each
+// quadrant of circular arcs.
+func (p *BufParams) QuadrantSegments() int {
+	p.context.mutex.Lock()
+	defer p.context.mutex.Unlock()
+	return p.value.QuadrantSegments
+}
+
 // SetEndCapStyle sets p's end cap style.
 func (p *BufParams) SetEndCapStyle(style BufCapStyle) *BufParams {
 	p.context.mutex.Lock()
@@ -35,6 +121,7 @@ func (p *BufParams) SetEndCapStyle(style BufCapStyle) *BufParams {
 	if C.GEOSBufferParams_setEndCapStyle_r(p.context.cHandle, p.cBufParams, C.int(style)) != 1 {
 		panic(p.context.err)
 	}
+	p.value.EndCapStyle = style
 	return p
 }
 
@@ -45,6 +132,7 @@ func (p *BufParams) SetJoinStyle(style BufJoinStyle) *BufParams {
 	if C.GEOSBufferParams_setJoinStyle_r(p.context.cHandle, p.cBufParams, C.int(style)) != 1 {
 		panic(p.context.err)
 	}
+	p.value.JoinStyle = style
 	return p
 }
 
@@ -55,6 +143,7 @@ func (p *BufParams) SetMitreLimit(mitreLimit float64) *BufParams {
 	if C.GEOSBufferParams_setMitreLimit_r(p.context.cHandle, p.cBufParams, C.double(mitreLimit)) != 1 {
 		panic(p.context.err)
 	}
+	p.value.MitreLimit = mitreLimit
 	return p
 }
 
@@ -66,6 +155,7 @@ func (p *BufParams) SetQuadrantSegments(quadSegs int) *BufParams {
 	if C.GEOSBufferParams_setQuadrantSegments_r(p.context.cHandle, p.cBufParams, C.int(quadSegs)) != 1 {
 		panic(p.context.err)
 	}
+	p.value.QuadrantSegments = quadSegs
 	return p
 }
 
@@ -76,9 +166,97 @@ func (p *BufParams) SetSingleSided(singleSided bool) *BufParams {
 	if C.GEOSBufferParams_setSingleSided_r(p.context.cHandle, p.cBufParams, toInt[C.int](singleSided)) != 1 {
 		panic(p.context.err)
 	}
+	p.value.SingleSided = singleSided
 	return p
 }
 
+// SingleSided returns whether the computed buffer should be single sided.
+func (p *BufParams) SingleSided() bool {
+	p.context.mutex.Lock()
+	defer p.context.mutex.Unlock()
+	return p.value.SingleSided
+}
+
+// Value returns p's parameters.
+func (p *BufParams) Value() BufParamsValue {
+	p.context.mutex.Lock()
+	defer p.context.mutex.Unlock()
+	return p.value
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s BufCapStyle) MarshalText() ([]byte, error) {
+	str, ok := bufCapStyleStrings[s]
+	if !ok {
+		return nil, fmt.Errorf("%d: %w", int(s), errInvalidBufCapStyle)
+	}
+	return []byte(str), nil
+}
+
+// String returns s's name.
+func (s BufCapStyle) String() string {
+	if str, ok := bufCapStyleStrings[s]; ok {
+		return str
+	}
+	return fmt.Sprintf("BufCapStyle(%d)", int(s))
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *BufCapStyle) UnmarshalText(text []byte) error {
+	for style, str := range bufCapStyleStrings {
+		if string(text) == str {
+			*s = style
+			return nil
+		}
+	}
+	return fmt.Errorf("%q: %w", text, errInvalidBufCapStyle)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s BufJoinStyle) MarshalText() ([]byte, error) {
+	str, ok := bufJoinStyleStrings[s]
+	if !ok {
+		return nil, fmt.Errorf("%d: %w", int(s), errInvalidBufJoinStyle)
+	}
+	return []byte(str), nil
+}
+
+// String returns s's name.
+func (s BufJoinStyle) String() string {
+	if str, ok := bufJoinStyleStrings[s]; ok {
+		return str
+	}
+	return fmt.Sprintf("BufJoinStyle(%d)", int(s))
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *BufJoinStyle) UnmarshalText(text []byte) error {
+	for style, str := range bufJoinStyleStrings {
+		if string(text) == str {
+			*s = style
+			return nil
+		}
+	}
+	return fmt.Errorf("%q: %w", text, errInvalidBufJoinStyle)
+}
+
+// withDefaults returns v with zero values replaced by GEOS's defaults.
+func (v BufParamsValue) withDefaults() BufParamsValue {
+	if v.EndCapStyle == 0 {
+		v.EndCapStyle = DefaultBufEndCapStyle
+	}
+	if v.JoinStyle == 0 {
+		v.JoinStyle = DefaultBufJoinStyle
+	}
+	if v.MitreLimit == 0 {
+		v.MitreLimit = DefaultBufMitreLimit
+	}
+	if v.QuadrantSegments == 0 {
+		v.QuadrantSegments = DefaultBufQuadrantSegments
+	}
+	return v
+}
+
 func (c *Context) destroyBufParams(cBufParams *C.struct_GEOSBufParams_t) {
 	c.mutex.Lock()
 	defer c.mutex.Unlock()
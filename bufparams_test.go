@@ -1,6 +1,7 @@
 package geos_test
 
 import (
+	"encoding/json"
 	"runtime"
 	"testing"
 
@@ -24,3 +25,59 @@ func TestBufferWithParams(t *testing.T) {
 	assert.Equal(t, geos.TypeIDPolygon, g.TypeID())
 	assert.Equal(t, [][]float64{{1, 0}, {0, 0}, {0, 1}, {1, 1}, {1, 0}}, g.ExteriorRing().CoordSeq().ToCoords())
 }
+
+func TestBufParamsValue(t *testing.T) {
+	defer runtime.GC() // Exercise finalizers.
+	c := geos.NewContext()
+
+	p := c.NewBufParams()
+	assert.Equal(t, geos.BufParamsValue{
+		EndCapStyle:      geos.BufCapStyleRound,
+		JoinStyle:        geos.BufJoinStyleRound,
+		MitreLimit:       5,
+		QuadrantSegments: 8,
+	}, p.Value())
+
+	p.SetEndCapStyle(geos.BufCapStyleFlat).SetJoinStyle(geos.BufJoinStyleMitre).SetMitreLimit(2).SetQuadrantSegments(4).SetSingleSided(true)
+	assert.Equal(t, geos.BufCapStyleFlat, p.EndCapStyle())
+	assert.Equal(t, geos.BufJoinStyleMitre, p.JoinStyle())
+	assert.Equal(t, 2, p.MitreLimit())
+	assert.Equal(t, 4, p.QuadrantSegments())
+	assert.True(t, p.SingleSided())
+
+	clone := p.Clone()
+	assert.Equal(t, p.Value(), clone.Value())
+	clone.SetSingleSided(false)
+	assert.True(t, p.SingleSided())
+	assert.False(t, clone.SingleSided())
+
+	data, err := json.Marshal(p.Value())
+	assert.NoError(t, err)
+	assert.Equal(t, `{"endCapStyle":"flat","joinStyle":"mitre","mitreLimit":2,"quadrantSegments":4,"singleSided":true}`, string(data))
+
+	var value geos.BufParamsValue
+	assert.NoError(t, json.Unmarshal([]byte(`{"endCapStyle":"square"}`), &value))
+	assert.Equal(t, geos.BufParamsValue{EndCapStyle: geos.BufCapStyleSquare}, value)
+	assert.Equal(t, geos.BufParamsValue{
+		EndCapStyle:      geos.BufCapStyleSquare,
+		JoinStyle:        geos.BufJoinStyleRound,
+		MitreLimit:       5,
+		QuadrantSegments: 8,
+	}, c.NewBufParamsFromValue(value).Value())
+
+	assert.Error(t, json.Unmarshal([]byte(`{"joinStyle":"pointy"}`), &value))
+}
+
+func TestGeomSingleSidedBuffer(t *testing.T) {
+	defer runtime.GC() // Exercise finalizers.
+	c := geos.NewContext()
+	g := mustNewGeomFromWKT(t, c, "LINESTRING (0 0, 1 0)")
+
+	left := g.SingleSidedBuffer(1, geos.BufSideLeft)
+	assert.Equal(t, 1, left.Area())
+	assert.Equal(t, geos.NewBox2D(0, 0, 1, 1), left.Bounds())
+
+	right := g.SingleSidedBuffer(1, geos.BufSideRight)
+	assert.Equal(t, 1, right.Area())
+	assert.Equal(t, geos.NewBox2D(0, -1, 1, 0), right.Bounds())
+}
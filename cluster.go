@@ -0,0 +1,90 @@
+package geos
+
+// #include "go-geos.h"
+import "C"
+
+// ClusterNone is the cluster id of a geometry that is not in any cluster, for
+// example a noise point in ClusterDBSCAN.
+const ClusterNone = -1
+
+// ClusterDBSCAN clusters geoms using the DBSCAN algorithm with distance eps and
+// minimum cluster size minPoints. It returns the cluster id of each geometry,
+// or ClusterNone if the geometry is noise. It requires GEOS 3.14 or later.
+func (c *Context) ClusterDBSCAN(geoms []*Geom, eps float64, minPoints int) []int {
+	return c.cluster(geoms, C.c_GEOS_CLUSTER_DBSCAN, eps, minPoints)
+}
+
+// ClusterEnvelopeDistance clusters geoms whose envelopes are within distance
+// of each other. It returns the cluster id of each geometry. It requires GEOS
+// 3.14 or later.
+func (c *Context) ClusterEnvelopeDistance(geoms []*Geom, distance float64) []int {
+	return c.cluster(geoms, C.c_GEOS_CLUSTER_ENVELOPE_DISTANCE, distance, 0)
+}
+
+// ClusterEnvelopeIntersects clusters geoms whose envelopes intersect. It
+// returns the cluster id of each geometry. It requires GEOS 3.14 or later.
+func (c *Context) ClusterEnvelopeIntersects(geoms []*Geom) []int {
+	return c.cluster(geoms, C.c_GEOS_CLUSTER_ENVELOPE_INTERSECTS, 0, 0)
+}
+
+// ClusterGeometryDistance clusters geoms that are within distance of each
+// other. It returns the cluster id of each geometry. It requires GEOS 3.14 or
+// later.
+func (c *Context) ClusterGeometryDistance(geoms []*Geom, distance float64) []int {
+	return c.cluster(geoms, C.c_GEOS_CLUSTER_GEOMETRY_DISTANCE, distance, 0)
+}
+
+// ClusterGeometryIntersects clusters geoms that intersect. It returns the
+// cluster id of each geometry. It requires GEOS 3.14 or later.
+func (c *Context) ClusterGeometryIntersects(geoms []*Geom) []int {
+	return c.cluster(geoms, C.c_GEOS_CLUSTER_GEOMETRY_INTERSECTS, 0, 0)
+}
+
+// GroupClusters returns a GeometryCollection for each cluster containing
+// copies of the geoms in that cluster, as identified by clusterIDs. The ith
+// collection contains the geometries with cluster id i. Geometries with
+// cluster id ClusterNone are omitted.
+func (c *Context) GroupClusters(geoms []*Geom, clusterIDs []int) []*Geom {
+	if len(clusterIDs) != len(geoms) {
+		panic(errIndexOutOfRange)
+	}
+	var clusters [][]*Geom
+	for i, clusterID := range clusterIDs {
+		switch {
+		case clusterID == ClusterNone:
+			continue
+		case clusterID < 0:
+			panic(errIndexOutOfRange)
+		case clusterID >= len(clusters):
+			clusters = append(clusters, make([][]*Geom, clusterID-len(clusters)+1)...)
+		}
+		clusters[clusterID] = append(clusters[clusterID], geoms[i].Clone())
+	}
+	collections := make([]*Geom, len(clusters))
+	for i, cluster := range clusters {
+		collections[i] = c.NewCollection(TypeIDGeometryCollection, cluster)
+	}
+	return collections
+}
+
+func (c *Context) cluster(geoms []*Geom, method C.enum_c_GEOSClusterMethod, d float64, minPoints int) []int {
+	if VersionCompare(3, 14, 0) < 0 {
+		panic(errUnsupportedGEOSVersion)
+	}
+	if len(geoms) == 0 {
+		return nil
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	cGeoms, unlockFunc := c.cGeomsLocked(geoms)
+	defer unlockFunc()
+	cClusterIDs := make([]C.int, len(geoms))
+	if C.c_GEOSCluster_r(c.cHandle, cGeoms, C.uint(len(geoms)), method, C.double(d), C.uint(minPoints), &cClusterIDs[0]) == -1 {
+		panic(c.err)
+	}
+	clusterIDs := make([]int, len(geoms))
+	for i, cClusterID := range cClusterIDs {
+		clusterIDs[i] = int(cClusterID)
+	}
+	return clusterIDs
+}
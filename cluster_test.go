@@ -0,0 +1,70 @@
+package geos_test
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+
+	"github.com/twpayne/go-geos"
+)
+
+func TestCluster(t *testing.T) {
+	if geos.VersionCompare(3, 14, 0) < 0 {
+		t.Skip("clustering requires GEOS 3.14 or later")
+	}
+	defer runtime.GC() // Exercise finalizers.
+	c := geos.NewContext()
+	points := []*geos.Geom{
+		mustNewGeomFromWKT(t, c, "POINT (0 0)"),
+		mustNewGeomFromWKT(t, c, "POINT (1 0)"),
+		mustNewGeomFromWKT(t, c, "POINT (10 10)"),
+		mustNewGeomFromWKT(t, c, "POINT (11 10)"),
+		mustNewGeomFromWKT(t, c, "POINT (50 50)"),
+	}
+
+	clusterIDs := c.ClusterDBSCAN(points, 2, 2)
+	assert.Equal(t, 5, len(clusterIDs))
+	assert.Equal(t, clusterIDs[0], clusterIDs[1])
+	assert.Equal(t, clusterIDs[2], clusterIDs[3])
+	assert.NotEqual(t, clusterIDs[0], clusterIDs[2])
+	assert.NotEqual(t, geos.ClusterNone, clusterIDs[0])
+	assert.NotEqual(t, geos.ClusterNone, clusterIDs[2])
+	assert.Equal(t, geos.ClusterNone, clusterIDs[4])
+
+	clusters := c.GroupClusters(points, clusterIDs)
+	assert.Equal(t, 2, len(clusters))
+	for _, cluster := range clusters {
+		assert.Equal(t, geos.TypeIDGeometryCollection, cluster.TypeID())
+		assert.Equal(t, 2, cluster.NumGeometries())
+	}
+
+	for _, clusterIDs := range [][]int{
+		c.ClusterGeometryDistance(points, 2),
+		c.ClusterEnvelopeDistance(points, 2),
+	} {
+		assert.Equal(t, clusterIDs[0], clusterIDs[1])
+		assert.Equal(t, clusterIDs[2], clusterIDs[3])
+		assert.NotEqual(t, clusterIDs[0], clusterIDs[2])
+		assert.NotEqual(t, clusterIDs[0], clusterIDs[4])
+		assert.NotEqual(t, clusterIDs[2], clusterIDs[4])
+		assert.NotEqual(t, geos.ClusterNone, clusterIDs[4])
+		assert.Equal(t, 3, len(c.GroupClusters(points, clusterIDs)))
+	}
+
+	polygons := []*geos.Geom{
+		mustNewGeomFromWKT(t, c, "POLYGON ((0 0, 1 0, 1 1, 0 1, 0 0))"),
+		mustNewGeomFromWKT(t, c, "POLYGON ((1 0, 2 0, 2 1, 1 1, 1 0))"),
+		mustNewGeomFromWKT(t, c, "POLYGON ((5 5, 6 5, 6 6, 5 6, 5 5))"),
+	}
+	for _, clusterIDs := range [][]int{
+		c.ClusterGeometryIntersects(polygons),
+		c.ClusterEnvelopeIntersects(polygons),
+	} {
+		assert.Equal(t, clusterIDs[0], clusterIDs[1])
+		assert.NotEqual(t, clusterIDs[0], clusterIDs[2])
+	}
+
+	assert.Zero(t, c.ClusterGeometryIntersects(nil))
+	assert.Panics(t, func() { c.GroupClusters(points, []int{0}) })
+}
@@ -5,9 +5,12 @@ package geos
 import "C"
 
 import (
+	"fmt"
 	"runtime"
 	"runtime/cgo"
 	"slices"
+	"strconv"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"unsafe"
@@ -25,18 +28,33 @@ type Context struct {
 	wkbReader          func() *WKBReader
 	wktReader          func() *WKTReader
 	wktWriter          func() *WKTWriter
+	wktWriterOptions   []WKTWriterOption
 	err                error
 	errPHandle         cgo.Handle
 }
 
-// NewContext returns a new Context.
-func NewContext() *Context {
+// A ContextOption sets an option on a Context.
+type ContextOption func(*Context)
+
+// WithContextWKTWriterOptions sets the options used when writing WKT with
+// Geom.ToWKT and Geom.String.
+func WithContextWKTWriterOptions(options ...WKTWriterOption) ContextOption {
+	return func(c *Context) {
+		c.wktWriterOptions = options
+	}
+}
+
+// NewContext returns a new Context with the given options.
+func NewContext(options ...ContextOption) *Context {
 	cHandle := C.GEOS_init_r()
 	var refCount atomic.Int64
 	c := &Context{
 		cHandle:  cHandle,
 		refCount: &refCount,
 	}
+	for _, option := range options {
+		option(c)
+	}
 	c.ref()
 	runtime.AddCleanup(c, func(cHandle C.GEOSContextHandle_t) {
 		// Inline unref here so that the cleanup function does not hold a
@@ -67,7 +85,7 @@ func NewContext() *Context {
 		return c.NewWKTReader()
 	})
 	c.wktWriter = sync.OnceValue(func() *WKTWriter {
-		return c.NewWKTWriter()
+		return c.NewWKTWriter(c.wktWriterOptions...)
 	})
 	c.errPHandle = cgo.NewHandle(&c.err)
 	runtime.AddCleanup(c, cgo.Handle.Delete, c.errPHandle)
@@ -95,19 +113,60 @@ func (c *Context) Clone(g *Geom) *Geom {
 	return clone
 }
 
+// NewGeomFromEWKT parses a geometry in Extended WKT format, for example
+// "SRID=4326;POINT (1 2)", from ewkt. The SRID prefix is optional.
+func (c *Context) NewGeomFromEWKT(ewkt string, options ...WKTReaderOption) (*Geom, error) {
+	srid := 0
+	wkt := ewkt
+	if len(ewkt) >= len(ewktSRIDPrefix) && strings.EqualFold(ewkt[:len(ewktSRIDPrefix)], ewktSRIDPrefix) {
+		sridStr, rest, ok := strings.Cut(ewkt[len(ewktSRIDPrefix):], ";")
+		if !ok {
+			return nil, fmt.Errorf("%q: %w", ewkt, errInvalidEWKT)
+		}
+		var err error
+		srid, err = strconv.Atoi(strings.TrimSpace(sridStr))
+		if err != nil {
+			return nil, fmt.Errorf("%q: %w", sridStr, errInvalidEWKT)
+		}
+		wkt = rest
+	}
+	g, err := c.NewGeomFromWKT(wkt, options...)
+	if err != nil {
+		return nil, err
+	}
+	if srid != 0 {
+		g.SetSRID(srid)
+	}
+	return g, nil
+}
+
 // NewGeomFromGeoJSON returns a new geometry in JSON format from json.
 func (c *Context) NewGeomFromGeoJSON(geoJSON string) (*Geom, error) {
 	return c.geoJSONReader().ReadGeometry(geoJSON)
 }
 
+// NewGeomFromHexWKB parses a geometry in hex-encoded WKB format from hex.
+func (c *Context) NewGeomFromHexWKB(hex string, options ...WKBReaderOption) (*Geom, error) {
+	if len(options) == 0 {
+		return c.wkbReader().ReadHex(hex)
+	}
+	return c.NewWKBReader(options...).ReadHex(hex)
+}
+
 // NewGeomFromWKB parses a geometry in WKB format from wkb.
-func (c *Context) NewGeomFromWKB(wkb []byte) (*Geom, error) {
-	return c.wkbReader().Read(wkb)
+func (c *Context) NewGeomFromWKB(wkb []byte, options ...WKBReaderOption) (*Geom, error) {
+	if len(options) == 0 {
+		return c.wkbReader().Read(wkb)
+	}
+	return c.NewWKBReader(options...).Read(wkb)
 }
 
 // NewGeomFromWKT parses a geometry in WKT format from wkt.
-func (c *Context) NewGeomFromWKT(wkt string) (*Geom, error) {
-	return c.wktReader().Read(wkt)
+func (c *Context) NewGeomFromWKT(wkt string, options ...WKTReaderOption) (*Geom, error) {
+	if len(options) == 0 {
+		return c.wktReader().Read(wkt)
+	}
+	return c.NewWKTReader(options...).Read(wkt)
 }
 
 // OrientationIndex returns the orientation index from A to B and then to P.
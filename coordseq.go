@@ -255,6 +255,9 @@ func (c *Context) newCoordsFromGEOSCoordSeq(cCoordSeq *C.struct_GEOSCoordSeq_t)
 	if C.GEOSCoordSeq_getSize_r(c.cHandle, cCoordSeq, &size) == 0 {
 		panic(c.err)
 	}
+	if size == 0 || dimensions == 0 {
+		return nil
+	}
 
 	var hasZ C.int
 	if dimensions > 2 {
@@ -8,6 +8,42 @@ func Clone(g *Geom) *Geom {
 	return DefaultContext.Clone(g)
 }
 
+// ClusterDBSCAN clusters geoms using the DBSCAN algorithm with distance eps and
+// minimum cluster size minPoints in the default context.
+func ClusterDBSCAN(geoms []*Geom, eps float64, minPoints int) []int {
+	return DefaultContext.ClusterDBSCAN(geoms, eps, minPoints)
+}
+
+// ClusterEnvelopeDistance clusters geoms whose envelopes are within distance
+// of each other in the default context.
+func ClusterEnvelopeDistance(geoms []*Geom, distance float64) []int {
+	return DefaultContext.ClusterEnvelopeDistance(geoms, distance)
+}
+
+// ClusterEnvelopeIntersects clusters geoms whose envelopes intersect in the
+// default context.
+func ClusterEnvelopeIntersects(geoms []*Geom) []int {
+	return DefaultContext.ClusterEnvelopeIntersects(geoms)
+}
+
+// ClusterGeometryDistance clusters geoms that are within distance of each
+// other in the default context.
+func ClusterGeometryDistance(geoms []*Geom, distance float64) []int {
+	return DefaultContext.ClusterGeometryDistance(geoms, distance)
+}
+
+// ClusterGeometryIntersects clusters geoms that intersect in the default
+// context.
+func ClusterGeometryIntersects(geoms []*Geom) []int {
+	return DefaultContext.ClusterGeometryIntersects(geoms)
+}
+
+// GroupClusters returns a GeometryCollection for each cluster in the default
+// context.
+func GroupClusters(geoms []*Geom, clusterIDs []int) []*Geom {
+	return DefaultContext.GroupClusters(geoms, clusterIDs)
+}
+
 // NewGeomFromBounds returns a new polygon populated with bounds.
 func NewGeomFromBounds(minX, minY, maxX, maxY float64) *Geom {
 	return DefaultContext.NewGeomFromBounds(minX, minY, maxX, maxY)
@@ -48,19 +84,29 @@ func NewEmptyPolygon() *Geom {
 	return DefaultContext.NewEmptyPolygon()
 }
 
+// NewGeomFromEWKT parses a geometry in Extended WKT format from ewkt.
+func NewGeomFromEWKT(ewkt string, options ...WKTReaderOption) (*Geom, error) {
+	return DefaultContext.NewGeomFromEWKT(ewkt, options...)
+}
+
 // NewGeomFromGeoJSON parses a geometry in GeoJSON format from GeoJSON.
 func NewGeomFromGeoJSON(geoJSON string) (*Geom, error) {
 	return DefaultContext.NewGeomFromGeoJSON(geoJSON)
 }
 
+// NewGeomFromHexWKB parses a geometry in hex-encoded WKB format from hex.
+func NewGeomFromHexWKB(hex string, options ...WKBReaderOption) (*Geom, error) {
+	return DefaultContext.NewGeomFromHexWKB(hex, options...)
+}
+
 // NewGeomFromWKB parses a geometry in WKB format from wkb.
-func NewGeomFromWKB(wkb []byte) (*Geom, error) {
-	return DefaultContext.NewGeomFromWKB(wkb)
+func NewGeomFromWKB(wkb []byte, options ...WKBReaderOption) (*Geom, error) {
+	return DefaultContext.NewGeomFromWKB(wkb, options...)
 }
 
 // NewGeomFromWKT parses a geometry in WKT format from wkt.
-func NewGeomFromWKT(wkt string) (*Geom, error) {
-	return DefaultContext.NewGeomFromWKT(wkt)
+func NewGeomFromWKT(wkt string, options ...WKTReaderOption) (*Geom, error) {
+	return DefaultContext.NewGeomFromWKT(wkt, options...)
 }
 
 // NewLinearRing returns a new linear ring populated with coords.
@@ -7,10 +7,19 @@ package geos
 import "C"
 
 import (
+	"hash/fnv"
+	"math"
 	"runtime"
+	"strconv"
 	"unsafe"
 )
 
+// Subdivision limits.
+const (
+	subdivideMinVertices = 5
+	subdivideMaxDepth    = 50
+)
+
 // A Geom is a geometry.
 type Geom struct {
 	context          *Context
@@ -186,6 +195,16 @@ func (g *Geom) Bounds() *Box2D {
 	return bounds
 }
 
+// Bounds3D returns g's three-dimensional bounds. If g has no Z coordinates
+// then MinZ is +Inf and MaxZ is -Inf, and the bounds have no Z range.
+func (g *Geom) Bounds3D() *Box3D {
+	bounds := NewBox3DEmpty()
+	g.context.mutex.Lock()
+	defer g.context.mutex.Unlock()
+	C.c_GEOSGeomBounds3D_r(g.context.cHandle, g.cGeom, (*C.double)(&bounds.MinX), (*C.double)(&bounds.MinY), (*C.double)(&bounds.MinZ), (*C.double)(&bounds.MaxX), (*C.double)(&bounds.MaxY), (*C.double)(&bounds.MaxZ))
+	return bounds
+}
+
 // MakeValidWithParams returns a new valid geometry using the MakeValidMethods
 // and MakeValidCollapsed parameters.
 func (g *Geom) MakeValidWithParams(method MakeValidMethod, collapse MakeValidCollapsed) *Geom {
@@ -206,6 +225,13 @@ func (g *Geom) BufferWithParams(bufParams *BufParams, width float64) *Geom {
 	return g.context.newNonNilGeom(C.GEOSBufferWithParams_r(g.context.cHandle, g.cGeom, bufParams.cBufParams, C.double(width)), nil)
 }
 
+// SingleSidedBuffer returns the buffer of width on side of g, which should be
+// lineal.
+func (g *Geom) SingleSidedBuffer(width float64, side BufSide) *Geom {
+	bufParams := g.context.NewBufParams().SetSingleSided(true)
+	return g.BufferWithParams(bufParams, float64(side)*math.Abs(width))
+}
+
 // VoronoiDiagram returns the Voronoi diagram of the vertices of g.
 func (g *Geom) VoronoiDiagram(env *Geom, tolerance float64, flags VoronoiDiagramFlag) *Geom {
 	g.context.mutex.Lock()
@@ -235,6 +261,27 @@ func (g *Geom) CoordSeq() *CoordSeq {
 	return g.context.newCoordSeqInternal(cCoordSeq, g)
 }
 
+// CoordinateDimension returns the number of dimensions of g's coordinates,
+// which is 2 for XY, 3 for XYZ or XYM, and 4 for XYZM.
+func (g *Geom) CoordinateDimension() int {
+	g.context.mutex.Lock()
+	defer g.context.mutex.Unlock()
+	coordinateDimension := C.GEOSGeom_getCoordinateDimension_r(g.context.cHandle, g.cGeom)
+	if coordinateDimension == 0 {
+		panic(g.context.err)
+	}
+	return int(coordinateDimension)
+}
+
+// Dimension returns g's topological dimension: DimensionPoint for points,
+// DimensionLine for lines, and DimensionArea for polygons. Collections return
+// the largest dimension of their components.
+func (g *Geom) Dimension() Dimension {
+	g.context.mutex.Lock()
+	defer g.context.mutex.Unlock()
+	return Dimension(C.GEOSGeom_getDimensions_r(g.context.cHandle, g.cGeom))
+}
+
 // ExteriorRing returns the exterior ring. The returned geometry is a
 // sub-geometry of g and will keep it alive.
 func (g *Geom) ExteriorRing() *Geom {
@@ -277,6 +324,16 @@ func (g *Geom) ReleaseCollection() []*Geom {
 	return result
 }
 
+// Hash returns a hash of g's normalized WKB. If gridSize is positive then g's
+// coordinates are first snapped to a grid of gridSize. Geometries that are
+// identical after normalization, see Normalize and EqualsIdentical, have the
+// same hash.
+func (g *Geom) Hash(gridSize float64) uint64 {
+	h := fnv.New64a()
+	h.Write(g.normalizedClone(gridSize).ToWKB())
+	return h.Sum64()
+}
+
 // InteriorRing returns the nth interior ring. The returned geometry is a
 // sub-geometry of g and will keep it alive.
 func (g *Geom) InteriorRing(n int) *Geom {
@@ -288,6 +345,33 @@ func (g *Geom) InteriorRing(n int) *Geom {
 	return g.context.newNonNilGeom(C.GEOSGetInteriorRingN_r(g.context.cHandle, g.cGeom, C.int(n)), g)
 }
 
+// IsCCW returns if g is counter-clockwise. g must be a LinearRing, a closed
+// LineString with at least four coordinates, or a non-empty Polygon, in which
+// case the orientation of its exterior ring is returned. It panics for other
+// geometries.
+func (g *Geom) IsCCW() bool {
+	g.context.mutex.Lock()
+	defer g.context.mutex.Unlock()
+	cGeom := g.cGeom
+	if g.typeID == TypeIDPolygon {
+		cGeom = C.GEOSGetExteriorRing_r(g.context.cHandle, g.cGeom)
+		if cGeom == nil {
+			panic(g.context.err)
+		}
+	}
+	cCoordSeq := C.GEOSGeom_getCoordSeq_r(g.context.cHandle, cGeom)
+	if cCoordSeq == nil {
+		panic(g.context.err)
+	}
+	var cIsCCW C.char
+	switch C.GEOSCoordSeq_isCCW_r(g.context.cHandle, cCoordSeq, &cIsCCW) {
+	case 1:
+		return cIsCCW != 0
+	default:
+		panic(g.context.err)
+	}
+}
+
 // IsValidReason returns the reason that g is invalid.
 func (g *Geom) IsValidReason() string {
 	g.context.mutex.Lock()
@@ -348,6 +432,19 @@ func (g *Geom) NumPoints() int {
 	return g.numPoints
 }
 
+// OrientPolygons orients the rings of all polygons in g in place, with
+// exterior rings clockwise if exteriorCW is true and counter-clockwise
+// otherwise, and returns g. Interior rings are given the opposite orientation.
+// RFC 7946 GeoJSON requires counter-clockwise exterior rings.
+func (g *Geom) OrientPolygons(exteriorCW bool) *Geom {
+	g.context.mutex.Lock()
+	defer g.context.mutex.Unlock()
+	if C.GEOSOrientPolygons_r(g.context.cHandle, g.cGeom, toInt[C.int](exteriorCW)) != 0 {
+		panic(g.context.err)
+	}
+	return g
+}
+
 // Point returns the g's nth point.
 func (g *Geom) Point(n int) *Geom {
 	g.context.mutex.Lock()
@@ -395,6 +492,11 @@ func (g *Geom) RelatePattern(other *Geom, pat string) bool {
 	}
 }
 
+// RelateMatrix returns the DE-9IM intersection matrix for g and other.
+func (g *Geom) RelateMatrix(other *Geom) IntersectionMatrix {
+	return mustParseIntersectionMatrix(g.Relate(other))
+}
+
 // SRID returns g's SRID.
 func (g *Geom) SRID() int {
 	g.context.mutex.Lock()
@@ -427,11 +529,43 @@ func (g *Geom) String() string {
 	return g.ToWKT()
 }
 
+// Subdivide recursively divides g into pieces with at most maxVertices
+// coordinates each, in the manner of PostGIS's ST_Subdivide. g is split at the
+// middle of its longer side, or at a nearby vertex for polygons, and the halves
+// are clipped with ClipByBox2D. Collections are subdivided component by
+// component. The returned pieces are suitable for building spatial indexes
+// and are always new geometries, never g itself. maxVertices must be at least
+// 5. Recursion stops at a depth of 50, so pathological inputs may return
+// pieces with more than maxVertices coordinates.
+func (g *Geom) Subdivide(maxVertices int) []*Geom {
+	if maxVertices < subdivideMinVertices {
+		panic(errMaxVerticesOutOfRange)
+	}
+	return g.subdivide(maxVertices, 0, nil)
+}
+
+// ToEWKT returns g in Extended WKT format. If g has a non-zero SRID then it is
+// prefixed with "SRID=<srid>;", otherwise the result is the same as ToWKT.
+func (g *Geom) ToEWKT() string {
+	wkt := g.ToWKT()
+	srid := g.SRID()
+	if srid == 0 {
+		return wkt
+	}
+	return ewktSRIDPrefix + strconv.Itoa(srid) + ";" + wkt
+}
+
 // ToEWKBWithSRID returns g in Extended WKB format with its SRID.
 func (g *Geom) ToEWKBWithSRID() []byte {
 	return g.context.ewkbWithSRIDWriter().Write(g)
 }
 
+// ToEWKBHexWithSRID returns g in hexadecimal Extended WKB format with its
+// SRID.
+func (g *Geom) ToEWKBHexWithSRID() string {
+	return g.context.ewkbWithSRIDWriter().WriteHex(g)
+}
+
 // ToGeoJSON returns g in GeoJSON format.
 func (g *Geom) ToGeoJSON(indent int) string {
 	return g.context.geoJSONWriter().WriteGeometry(g, indent)
@@ -471,6 +605,85 @@ func (g *Geom) UserData() uintptr {
 	return uintptr(C.c_GEOSGeom_getUserData_r(g.context.cHandle, g.cGeom))
 }
 
+// normalizedClone returns a normalized clone of g, with its coordinates
+// snapped to a grid of gridSize if gridSize is positive.
+func (g *Geom) normalizedClone(gridSize float64) *Geom {
+	if gridSize > 0 {
+		return g.SetPrecision(gridSize, PrecisionRulePointwise).Normalize()
+	}
+	return g.Clone().Normalize()
+}
+
+// subdivide appends the pieces of g to pieces.
+func (g *Geom) subdivide(maxVertices, depth int, pieces []*Geom) []*Geom {
+	if g.IsEmpty() {
+		return pieces
+	}
+	switch g.typeID {
+	case TypeIDMultiPoint, TypeIDMultiLineString, TypeIDMultiPolygon, TypeIDGeometryCollection:
+		for i := range g.numGeometries {
+			pieces = g.Geometry(i).subdivide(maxVertices, depth, pieces)
+		}
+		return pieces
+	}
+
+	bounds := g.Bounds()
+	width, height := bounds.Width(), bounds.Height()
+	if g.NumCoordinates() <= maxVertices || depth >= subdivideMaxDepth || width == 0 && height == 0 {
+		// g is the caller's geometry at depth zero, and is owned by its
+		// collection if it is a member, so clone it to give the caller
+		// ownership of every piece.
+		if depth == 0 || g.owner != nil {
+			return append(pieces, g.Clone())
+		}
+		return append(pieces, g)
+	}
+
+	// Split across the longer side. For polygons, split at the exterior ring
+	// vertex closest to the center, if there is one strictly inside the
+	// bounds, to avoid introducing new vertices.
+	splitX := width >= height
+	var lower, upper, center float64
+	if splitX {
+		lower, upper = bounds.MinX, bounds.MaxX
+	} else {
+		lower, upper = bounds.MinY, bounds.MaxY
+	}
+	center = (lower + upper) / 2
+	if g.typeID == TypeIDPolygon {
+		pivot := math.Inf(1)
+		for _, coord := range g.ExteriorRing().CoordSeq().ToCoords() {
+			var ordinate float64
+			if splitX {
+				ordinate = coord[0]
+			} else {
+				ordinate = coord[1]
+			}
+			if lower < ordinate && ordinate < upper && math.Abs(ordinate-center) < math.Abs(pivot-center) {
+				pivot = ordinate
+			}
+		}
+		if !math.IsInf(pivot, 1) {
+			center = pivot
+		}
+	}
+
+	var halves [2]*Box2D
+	if splitX {
+		halves[0] = NewBox2D(bounds.MinX, bounds.MinY, center, bounds.MaxY)
+		halves[1] = NewBox2D(center, bounds.MinY, bounds.MaxX, bounds.MaxY)
+	} else {
+		halves[0] = NewBox2D(bounds.MinX, bounds.MinY, bounds.MaxX, center)
+		halves[1] = NewBox2D(bounds.MinX, center, bounds.MaxX, bounds.MaxY)
+	}
+	for _, half := range halves {
+		if clipped := g.ClipByBox2D(half); clipped != nil {
+			pieces = clipped.subdivide(maxVertices, depth+1, pieces)
+		}
+	}
+	return pieces
+}
+
 func (c *Context) newGeom(cGeom *C.struct_GEOSGeom_t, owner *Geom) *Geom {
 	if cGeom == nil {
 		return nil
@@ -566,6 +566,62 @@ func TestEWKBWithSRIDRoundTrip(t *testing.T) {
 	}
 }
 
+func TestEWKT(t *testing.T) {
+	defer runtime.GC() // Exercise finalizers.
+	c := geos.NewContext()
+	for _, tc := range []struct {
+		name         string
+		ewkt         string
+		expectedErr  bool
+		expectedSRID int
+		expectedEWKT string
+	}{
+		{
+			name:         "wkt",
+			ewkt:         "POINT (1 2)",
+			expectedEWKT: "POINT (1 2)",
+		},
+		{
+			name:         "srid",
+			ewkt:         "SRID=4326;POINT(1 2)",
+			expectedSRID: 4326,
+			expectedEWKT: "SRID=4326;POINT (1 2)",
+		},
+		{
+			name:         "lowercase",
+			ewkt:         "srid=3857;LINESTRING (0 0, 1 1)",
+			expectedSRID: 3857,
+			expectedEWKT: "SRID=3857;LINESTRING (0 0, 1 1)",
+		},
+		{
+			name:        "missing_semicolon",
+			ewkt:        "SRID=4326 POINT (1 2)",
+			expectedErr: true,
+		},
+		{
+			name:        "invalid_srid",
+			ewkt:        "SRID=abc;POINT (1 2)",
+			expectedErr: true,
+		},
+		{
+			name:        "invalid_wkt",
+			ewkt:        "SRID=4326;POINT (1)",
+			expectedErr: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			g, err := c.NewGeomFromEWKT(tc.ewkt)
+			if tc.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedSRID, g.SRID())
+			assert.Equal(t, tc.expectedEWKT, g.ToEWKT())
+		})
+	}
+}
+
 func TestGeomRelate(t *testing.T) {
 	c := geos.NewContext()
 	g1 := mustNewGeomFromWKT(t, c, "POINT (0 0)")
@@ -668,3 +724,148 @@ func TestReleaseCollection(t *testing.T) {
 		assert.Equal(t, 1, g1.NumGeometries())
 	}
 }
+
+func TestGeomSubdivide(t *testing.T) {
+	defer runtime.GC() // Exercise finalizers.
+	c := geos.NewContext()
+
+	circle := mustNewGeomFromWKT(t, c, "POINT (0 0)").Buffer(1, 16)
+	pieces := circle.Subdivide(10)
+	assert.True(t, len(pieces) > 1)
+	var area float64
+	for _, piece := range pieces {
+		assert.True(t, piece.NumCoordinates() <= 10)
+		area += piece.Area()
+	}
+	assert.True(t, math.Abs(circle.Area()-area) < 1e-6)
+
+	multiPoint := mustNewGeomFromWKT(t, c, "MULTIPOINT (0 0, 1 1)")
+	assert.Equal(t, 2, len(multiPoint.Subdivide(5)))
+
+	square := mustNewGeomFromWKT(t, c, "POLYGON ((0 0, 1 0, 1 1, 0 1, 0 0))")
+	squarePieces := square.Subdivide(5)
+	assert.Equal(t, 1, len(squarePieces))
+	assert.True(t, squarePieces[0] != square)
+	assert.True(t, squarePieces[0].Equals(square))
+
+	assert.Equal(t, 0, len(c.NewEmptyPolygon().Subdivide(5)))
+	assert.Panics(t, func() { square.Subdivide(4) })
+}
+
+func TestGeomOrientPolygons(t *testing.T) {
+	defer runtime.GC() // Exercise finalizers.
+	c := geos.NewContext()
+	polygon := mustNewGeomFromWKT(t, c, "POLYGON ((0 0, 3 0, 3 3, 0 3, 0 0), (1 1, 2 1, 2 2, 1 2, 1 1))")
+	assert.True(t, polygon.IsCCW())
+	assert.True(t, polygon.InteriorRing(0).IsCCW())
+	assert.Equal(t, polygon, polygon.OrientPolygons(true))
+	assert.False(t, polygon.IsCCW())
+	assert.True(t, polygon.InteriorRing(0).IsCCW())
+	assert.Equal(t, "POLYGON ((0 0, 0 3, 3 3, 3 0, 0 0), (1 1, 2 1, 2 2, 1 2, 1 1))", polygon.ToWKT())
+	polygon.OrientPolygons(false)
+	assert.True(t, polygon.IsCCW())
+	assert.False(t, polygon.InteriorRing(0).IsCCW())
+	assert.Equal(t, "POLYGON ((0 0, 3 0, 3 3, 0 3, 0 0), (1 1, 1 2, 2 2, 2 1, 1 1))", polygon.ToWKT())
+	assert.False(t, mustNewGeomFromWKT(t, c, "LINEARRING (0 0, 0 1, 1 1, 0 0)").IsCCW())
+	assert.True(t, mustNewGeomFromWKT(t, c, "LINESTRING (0 0, 1 0, 1 1, 0 0)").IsCCW())
+	assert.Panics(t, func() { mustNewGeomFromWKT(t, c, "POINT (0 0)").IsCCW() })
+	assert.Panics(t, func() { mustNewGeomFromWKT(t, c, "LINESTRING (0 0, 1 0, 1 1)").IsCCW() })
+	assert.Panics(t, func() { c.NewEmptyPolygon().IsCCW() })
+}
+
+func TestGeomRemoveRepeatedPoints(t *testing.T) {
+	defer runtime.GC() // Exercise finalizers.
+	c := geos.NewContext()
+	lineString := mustNewGeomFromWKT(t, c, "LINESTRING (0 0, 0 0, 1 1, 1 1.05, 2 2)")
+	assert.Equal(t, "LINESTRING (0 0, 1 1, 1 1.05, 2 2)", lineString.RemoveRepeatedPoints(0).ToWKT())
+	assert.Equal(t, "LINESTRING (0 0, 1 1, 2 2)", lineString.RemoveRepeatedPoints(0.1).ToWKT())
+}
+
+func TestGeomLineMergeDirected(t *testing.T) {
+	defer runtime.GC() // Exercise finalizers.
+	c := geos.NewContext()
+	consistent := mustNewGeomFromWKT(t, c, "MULTILINESTRING ((0 0, 1 0), (1 0, 2 0))")
+	assert.Equal(t, "LINESTRING (0 0, 1 0, 2 0)", consistent.LineMergeDirected().ToWKT())
+	inconsistent := mustNewGeomFromWKT(t, c, "MULTILINESTRING ((0 0, 1 0), (2 0, 1 0))")
+	assert.Equal(t, geos.TypeIDLineString, inconsistent.LineMerge().TypeID())
+	assert.Equal(t, 2, inconsistent.LineMergeDirected().NumGeometries())
+}
+
+func TestGeomDimensions(t *testing.T) {
+	for _, tc := range []struct {
+		wkt                         string
+		expectedDimension           geos.Dimension
+		expectedCoordinateDimension int
+		expectedBounds3D            *geos.Box3D
+	}{
+		{
+			wkt:                         "POINT (1 2)",
+			expectedDimension:           geos.DimensionPoint,
+			expectedCoordinateDimension: 2,
+			expectedBounds3D:            &geos.Box3D{MinX: 1, MinY: 2, MinZ: math.Inf(1), MaxX: 1, MaxY: 2, MaxZ: math.Inf(-1)},
+		},
+		{
+			wkt:                         "POINT Z (1 2 3)",
+			expectedDimension:           geos.DimensionPoint,
+			expectedCoordinateDimension: 3,
+			expectedBounds3D:            geos.NewBox3D(1, 2, 3, 1, 2, 3),
+		},
+		{
+			wkt:                         "LINESTRING Z (0 1 2, 3 4 5)",
+			expectedDimension:           geos.DimensionLine,
+			expectedCoordinateDimension: 3,
+			expectedBounds3D:            geos.NewBox3D(0, 1, 2, 3, 4, 5),
+		},
+		{
+			wkt:                         "POLYGON Z ((0 0 1, 1 0 2, 1 1 3, 0 1 -1, 0 0 1))",
+			expectedDimension:           geos.DimensionArea,
+			expectedCoordinateDimension: 3,
+			expectedBounds3D:            geos.NewBox3D(0, 0, -1, 1, 1, 3),
+		},
+		{
+			wkt:                         "GEOMETRYCOLLECTION Z (POINT Z (5 5 5), LINESTRING Z (0 0 0, 1 1 1))",
+			expectedDimension:           geos.DimensionLine,
+			expectedCoordinateDimension: 3,
+			expectedBounds3D:            geos.NewBox3D(0, 0, 0, 5, 5, 5),
+		},
+	} {
+		t.Run(tc.wkt, func(t *testing.T) {
+			defer runtime.GC() // Exercise finalizers.
+			c := geos.NewContext()
+			g := mustNewGeomFromWKT(t, c, tc.wkt)
+			assert.Equal(t, tc.expectedDimension, g.Dimension())
+			assert.Equal(t, tc.expectedCoordinateDimension, g.CoordinateDimension())
+			assert.Equal(t, tc.expectedBounds3D, g.Bounds3D())
+		})
+	}
+}
+
+func TestGeomUniquePoints(t *testing.T) {
+	defer runtime.GC() // Exercise finalizers.
+	c := geos.NewContext()
+	g := mustNewGeomFromWKT(t, c, "POLYGON ((0 0, 1 0, 1 1, 0 0))")
+	assert.Equal(t, "MULTIPOINT ((0 0), (1 0), (1 1))", g.UniquePoints().ToWKT())
+}
+
+func TestGeomHash(t *testing.T) {
+	defer runtime.GC() // Exercise finalizers.
+	c := geos.NewContext()
+	g := mustNewGeomFromWKT(t, c, "POLYGON ((0 0, 1 0, 1 1, 0 1, 0 0))")
+	assert.Equal(t, g.Hash(0), mustNewGeomFromWKT(t, c, "POLYGON ((0 0, 0 1, 1 1, 1 0, 0 0))").Hash(0))
+	assert.NotEqual(t, g.Hash(0), mustNewGeomFromWKT(t, c, "POLYGON ((0 0, 2 0, 2 2, 0 2, 0 0))").Hash(0))
+	assert.Equal(t, "POLYGON ((0 0, 1 0, 1 1, 0 1, 0 0))", g.ToWKT())
+
+	snapped := mustNewGeomFromWKT(t, c, "POLYGON ((0.01 0, 1 0, 1 1.01, 0 1, 0.01 0))")
+	assert.NotEqual(t, g.Hash(0), snapped.Hash(0))
+	assert.Equal(t, g.Hash(0.1), snapped.Hash(0.1))
+}
+
+func TestGeomEqualsIdentical(t *testing.T) {
+	defer runtime.GC() // Exercise finalizers.
+	c := geos.NewContext()
+	g := mustNewGeomFromWKT(t, c, "LINESTRING (0 0, 1 1)")
+	assert.True(t, g.EqualsIdentical(mustNewGeomFromWKT(t, c, "LINESTRING (0 0, 1 1)")))
+	assert.False(t, g.EqualsIdentical(mustNewGeomFromWKT(t, c, "LINESTRING (1 1, 0 0)")))
+	assert.False(t, g.EqualsIdentical(mustNewGeomFromWKT(t, c, "LINESTRING Z (0 0 0, 1 1 0)")))
+	assert.False(t, g.EqualsIdentical(mustNewGeomFromWKT(t, c, "MULTILINESTRING ((0 0, 1 1))")))
+}
@@ -175,6 +175,13 @@ func TestGeometry(t *testing.T) {
 					data := &strings.Builder{}
 					assert.NoError(t, xml.NewEncoder(data).Encode(tc.geometry))
 					assert.Equal(t, tc.expectedKML, data.String())
+					actualG, err := geometry.NewGeometryFromKML([]byte(data.String()))
+					assert.NoError(t, err)
+					if tc.geometry.IsEmpty() {
+						assert.True(t, actualG.IsEmpty())
+					} else {
+						assert.True(t, actualG.Equals(tc.geometry.Geom))
+					}
 				})
 			}
 			t.Run("sql", func(t *testing.T) {
@@ -0,0 +1,435 @@
+package geometry
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/twpayne/go-geos"
+)
+
+// gmlNamespace is the GML 3.2 namespace.
+const gmlNamespace = "http://www.opengis.net/gml/3.2"
+
+var (
+	gmlNamespaceAttr = xml.Attr{Name: xml.Name{Local: "xmlns:gml"}, Value: gmlNamespace}
+
+	gmlPointStartElement           = xml.StartElement{Name: xml.Name{Local: "gml:Point"}}
+	gmlLineStringStartElement      = xml.StartElement{Name: xml.Name{Local: "gml:LineString"}}
+	gmlLinearRingStartElement      = xml.StartElement{Name: xml.Name{Local: "gml:LinearRing"}}
+	gmlPolygonStartElement         = xml.StartElement{Name: xml.Name{Local: "gml:Polygon"}}
+	gmlMultiPointStartElement      = xml.StartElement{Name: xml.Name{Local: "gml:MultiPoint"}}
+	gmlMultiCurveStartElement      = xml.StartElement{Name: xml.Name{Local: "gml:MultiCurve"}}
+	gmlMultiSurfaceStartElement    = xml.StartElement{Name: xml.Name{Local: "gml:MultiSurface"}}
+	gmlMultiGeometryStartElement   = xml.StartElement{Name: xml.Name{Local: "gml:MultiGeometry"}}
+	gmlPosStartElement             = xml.StartElement{Name: xml.Name{Local: "gml:pos"}}
+	gmlPosListStartElement         = xml.StartElement{Name: xml.Name{Local: "gml:posList"}}
+	gmlExteriorStartElement        = xml.StartElement{Name: xml.Name{Local: "gml:exterior"}}
+	gmlInteriorStartElement        = xml.StartElement{Name: xml.Name{Local: "gml:interior"}}
+	gmlPointMemberStartElement     = xml.StartElement{Name: xml.Name{Local: "gml:pointMember"}}
+	gmlCurveMemberStartElement     = xml.StartElement{Name: xml.Name{Local: "gml:curveMember"}}
+	gmlSurfaceMemberStartElement   = xml.StartElement{Name: xml.Name{Local: "gml:surfaceMember"}}
+	gmlGeometryMemberStartElement  = xml.StartElement{Name: xml.Name{Local: "gml:geometryMember"}}
+	gmlMemberStartElementsByTypeID = map[geos.TypeID][2]xml.StartElement{
+		geos.TypeIDMultiPoint:         {gmlMultiPointStartElement, gmlPointMemberStartElement},
+		geos.TypeIDMultiLineString:    {gmlMultiCurveStartElement, gmlCurveMemberStartElement},
+		geos.TypeIDMultiPolygon:       {gmlMultiSurfaceStartElement, gmlSurfaceMemberStartElement},
+		geos.TypeIDGeometryCollection: {gmlMultiGeometryStartElement, gmlGeometryMemberStartElement},
+	}
+
+	// gmlMemberTypeIDs are the types of the members of each homogeneous
+	// collection type.
+	gmlMemberTypeIDs = map[geos.TypeID]geos.TypeID{
+		geos.TypeIDMultiPoint:      geos.TypeIDPoint,
+		geos.TypeIDMultiLineString: geos.TypeIDLineString,
+		geos.TypeIDMultiPolygon:    geos.TypeIDPolygon,
+	}
+
+	// gmlLatLonSRIDs are the SRIDs whose axis order is latitude, longitude
+	// when identified by an OGC URN or URL.
+	gmlLatLonSRIDs = map[int]bool{
+		4326: true,
+	}
+
+	errInvalidGMLLinearRing   = errors.New("invalid GML LinearRing")
+	errInvalidGMLLineString   = errors.New("invalid GML LineString")
+	errInvalidGMLMember       = errors.New("invalid GML member")
+	errInvalidGMLSrsDimension = errors.New("invalid GML srsDimension")
+)
+
+// A GMLGeometry is a Geometry that is marshaled to and unmarshaled from GML
+// 3.2, rather than KML.
+type GMLGeometry Geometry
+
+// An xmlNode is a generic XML element, used when decoding GML and KML.
+type xmlNode struct {
+	XMLName  xml.Name
+	Attrs    []xml.Attr `xml:",any,attr"`
+	CharData string     `xml:",chardata"`
+	Children []xmlNode  `xml:",any"`
+}
+
+// isLineStringCoords returns true if coords are valid for a non-empty
+// LineString.
+func isLineStringCoords(coords [][]float64) bool {
+	return len(coords) >= 2
+}
+
+// isLinearRingCoords returns true if coords are valid for a non-empty
+// LinearRing.
+func isLinearRingCoords(coords [][]float64) bool {
+	return len(coords) >= 4 && slices.Equal(coords[0], coords[len(coords)-1])
+}
+
+// A gmlSRS is the spatial reference system in scope while decoding GML.
+type gmlSRS struct {
+	srid    int
+	latLon  bool
+	srsDims int
+}
+
+// NewGeometryFromGML returns a new Geometry from GML 3.2.
+func NewGeometryFromGML(gml []byte) (*Geometry, error) {
+	var g GMLGeometry
+	if err := xml.Unmarshal(gml, &g); err != nil {
+		return nil, err
+	}
+	return (*Geometry)(&g), nil
+}
+
+// AsGML returns the GML 3.2 representation of g. If g's SRID is non-zero then
+// it is written as an srsName URN. EPSG:4326 coordinates are written in
+// latitude, longitude order.
+func (g *Geometry) AsGML() ([]byte, error) {
+	return xml.Marshal((*GMLGeometry)(g))
+}
+
+// MarshalXML implements encoding/xml.Marshaler.
+func (g *GMLGeometry) MarshalXML(e *xml.Encoder, _ xml.StartElement) error {
+	attrs := []xml.Attr{gmlNamespaceAttr}
+	srid := g.SRID()
+	if srid != 0 {
+		attrs = append(attrs, xml.Attr{Name: xml.Name{Local: "srsName"}, Value: "urn:ogc:def:crs:EPSG::" + strconv.Itoa(srid)})
+	}
+	if g.HasZ() {
+		attrs = append(attrs, xml.Attr{Name: xml.Name{Local: "srsDimension"}, Value: "3"})
+	}
+	return gmlEncodeGeom(e, g.Geom, attrs, gmlLatLonSRIDs[srid])
+}
+
+// UnmarshalXML implements encoding/xml.Unmarshaler.
+func (g *GMLGeometry) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var node xmlNode
+	if err := d.DecodeElement(&node, &start); err != nil {
+		return err
+	}
+	srs, err := gmlParseSRS(&node, gmlSRS{srsDims: 2})
+	if err != nil {
+		return err
+	}
+	geom, err := gmlDecodeGeom(&node, srs)
+	if err != nil {
+		return err
+	}
+	if srs.srid != 0 {
+		geom.SetSRID(srs.srid)
+	}
+	g.Geom = geom
+	return nil
+}
+
+func gmlEncodeElement(e *xml.Encoder, startElement xml.StartElement, f func() error) error {
+	if err := e.EncodeToken(startElement); err != nil {
+		return err
+	}
+	if err := f(); err != nil {
+		return err
+	}
+	return e.EncodeToken(startElement.End())
+}
+
+func gmlEncodeCoords(e *xml.Encoder, startElement xml.StartElement, coords [][]float64, latLon bool) error {
+	return gmlEncodeElement(e, startElement, func() error {
+		sb := &strings.Builder{}
+		sb.Grow(initialStringBufferSize)
+		for i, coord := range coords {
+			if latLon {
+				coord = append([]float64{coord[1], coord[0]}, coord[2:]...)
+			}
+			for j, ord := range coord {
+				if i != 0 || j != 0 {
+					sb.WriteByte(' ')
+				}
+				sb.WriteString(strconv.FormatFloat(ord, 'f', -1, 64))
+			}
+		}
+		return e.EncodeToken(xml.CharData(sb.String()))
+	})
+}
+
+func gmlEncodeGeom(e *xml.Encoder, geom *geos.Geom, attrs []xml.Attr, latLon bool) error {
+	withAttrs := func(startElement xml.StartElement) xml.StartElement {
+		startElement.Attr = attrs
+		return startElement
+	}
+	switch typeID := geom.TypeID(); typeID {
+	case geos.TypeIDPoint:
+		return gmlEncodeElement(e, withAttrs(gmlPointStartElement), func() error {
+			if geom.IsEmpty() {
+				return nil
+			}
+			return gmlEncodeCoords(e, gmlPosStartElement, geom.CoordSeq().ToCoords(), latLon)
+		})
+	case geos.TypeIDLineString:
+		return gmlEncodeElement(e, withAttrs(gmlLineStringStartElement), func() error {
+			return gmlEncodeCoords(e, gmlPosListStartElement, geom.CoordSeq().ToCoords(), latLon)
+		})
+	case geos.TypeIDLinearRing:
+		return gmlEncodeElement(e, withAttrs(gmlLinearRingStartElement), func() error {
+			return gmlEncodeCoords(e, gmlPosListStartElement, geom.CoordSeq().ToCoords(), latLon)
+		})
+	case geos.TypeIDPolygon:
+		return gmlEncodeElement(e, withAttrs(gmlPolygonStartElement), func() error {
+			if geom.IsEmpty() {
+				return nil
+			}
+			if err := gmlEncodeElement(e, gmlExteriorStartElement, func() error {
+				return gmlEncodeGeom(e, geom.ExteriorRing(), nil, latLon)
+			}); err != nil {
+				return err
+			}
+			for i, n := 0, geom.NumInteriorRings(); i < n; i++ {
+				if err := gmlEncodeElement(e, gmlInteriorStartElement, func() error {
+					return gmlEncodeGeom(e, geom.InteriorRing(i), nil, latLon)
+				}); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	case geos.TypeIDMultiPoint, geos.TypeIDMultiLineString, geos.TypeIDMultiPolygon, geos.TypeIDGeometryCollection:
+		startElements := gmlMemberStartElementsByTypeID[typeID]
+		return gmlEncodeElement(e, withAttrs(startElements[0]), func() error {
+			for i, n := 0, geom.NumGeometries(); i < n; i++ {
+				if err := gmlEncodeElement(e, startElements[1], func() error {
+					return gmlEncodeGeom(e, geom.Geometry(i), nil, latLon)
+				}); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	default:
+		return fmt.Errorf("unsupported type: %s", geom.Type())
+	}
+}
+
+// gmlParseSRS returns the spatial reference system of node, inheriting from
+// parent.
+func gmlParseSRS(node *xmlNode, parent gmlSRS) (gmlSRS, error) {
+	srs := parent
+	for _, attr := range node.Attrs {
+		switch attr.Name.Local {
+		case "srsName":
+			srs.srid, srs.latLon = gmlParseSrsName(attr.Value)
+		case "srsDimension":
+			srsDims, err := strconv.Atoi(attr.Value)
+			if err != nil || srsDims < 2 || 3 < srsDims {
+				return gmlSRS{}, fmt.Errorf("%q: %w", attr.Value, errInvalidGMLSrsDimension)
+			}
+			srs.srsDims = srsDims
+		}
+	}
+	return srs, nil
+}
+
+// gmlParseSrsName returns the SRID identified by srsName and whether its axis
+// order is latitude, longitude. The legacy "EPSG:n" and
+// "http://www.opengis.net/gml/srs/epsg.xml#n" forms always use longitude,
+// latitude order.
+func gmlParseSrsName(srsName string) (int, bool) {
+	var sridStr string
+	var ogc bool
+	switch {
+	case strings.HasPrefix(srsName, "urn:ogc:def:crs:EPSG:"):
+		sridStr = srsName[strings.LastIndexByte(srsName, ':')+1:]
+		ogc = true
+	case strings.HasPrefix(srsName, "http://www.opengis.net/def/crs/EPSG/"):
+		sridStr = srsName[strings.LastIndexByte(srsName, '/')+1:]
+		ogc = true
+	case strings.HasPrefix(srsName, "http://www.opengis.net/gml/srs/epsg.xml#"):
+		sridStr = strings.TrimPrefix(srsName, "http://www.opengis.net/gml/srs/epsg.xml#")
+	case strings.HasPrefix(srsName, "EPSG:"):
+		sridStr = strings.TrimPrefix(srsName, "EPSG:")
+	default:
+		return 0, false
+	}
+	srid, err := strconv.Atoi(sridStr)
+	if err != nil {
+		return 0, false
+	}
+	return srid, ogc && gmlLatLonSRIDs[srid]
+}
+
+// gmlDecodeCoords decodes the coordinates in the pos, posList, or coordinates
+// children of node.
+func gmlDecodeCoords(node *xmlNode, srs gmlSRS) ([][]float64, error) {
+	var coords [][]float64
+	for i := range node.Children {
+		child := &node.Children[i]
+		childSRS, err := gmlParseSRS(child, srs)
+		if err != nil {
+			return nil, err
+		}
+		var fields []string
+		switch child.XMLName.Local {
+		case "pos", "posList":
+			fields = strings.Fields(child.CharData)
+		case "coordinates":
+			fields = strings.FieldsFunc(child.CharData, func(r rune) bool {
+				return r == ',' || r == ' ' || r == '\t' || r == '\n' || r == '\r'
+			})
+			childSRS.srsDims = 0
+			if tuples := strings.Fields(child.CharData); len(tuples) > 0 {
+				childSRS.srsDims = strings.Count(tuples[0], ",") + 1
+			}
+		default:
+			continue
+		}
+		if childSRS.srsDims == 0 || len(fields)%childSRS.srsDims != 0 {
+			return nil, fmt.Errorf("%s: invalid number of ordinates", child.XMLName.Local)
+		}
+		for j := 0; j < len(fields); j += childSRS.srsDims {
+			coord := make([]float64, childSRS.srsDims)
+			for k := range coord {
+				ord, err := strconv.ParseFloat(fields[j+k], 64)
+				if err != nil {
+					return nil, err
+				}
+				coord[k] = ord
+			}
+			if childSRS.latLon {
+				coord[0], coord[1] = coord[1], coord[0]
+			}
+			coords = append(coords, coord)
+		}
+	}
+	return coords, nil
+}
+
+func gmlDecodeGeom(node *xmlNode, parentSRS gmlSRS) (*geos.Geom, error) {
+	srs, err := gmlParseSRS(node, parentSRS)
+	if err != nil {
+		return nil, err
+	}
+	switch node.XMLName.Local {
+	case "Point":
+		coords, err := gmlDecodeCoords(node, srs)
+		switch {
+		case err != nil:
+			return nil, err
+		case len(coords) == 0:
+			return geos.NewEmptyPoint(), nil
+		default:
+			return geos.NewPoint(coords[0]), nil
+		}
+	case "LineString", "LinearRing":
+		coords, err := gmlDecodeCoords(node, srs)
+		switch {
+		case err != nil:
+			return nil, err
+		case len(coords) == 0:
+			return geos.NewEmptyLineString(), nil
+		case node.XMLName.Local == "LinearRing" && !isLinearRingCoords(coords):
+			return nil, errInvalidGMLLinearRing
+		case !isLineStringCoords(coords):
+			return nil, errInvalidGMLLineString
+		default:
+			return geos.NewLineString(coords), nil
+		}
+	case "Polygon":
+		var exterior [][]float64
+		var interiors [][][]float64
+		for i := range node.Children {
+			child := &node.Children[i]
+			switch child.XMLName.Local {
+			case "exterior", "outerBoundaryIs", "interior", "innerBoundaryIs":
+			default:
+				continue
+			}
+			childSRS, err := gmlParseSRS(child, srs)
+			if err != nil {
+				return nil, err
+			}
+			for j := range child.Children {
+				ring := &child.Children[j]
+				if ring.XMLName.Local != "LinearRing" {
+					continue
+				}
+				ringSRS, err := gmlParseSRS(ring, childSRS)
+				if err != nil {
+					return nil, err
+				}
+				coords, err := gmlDecodeCoords(ring, ringSRS)
+				if err != nil {
+					return nil, err
+				}
+				if !isLinearRingCoords(coords) {
+					return nil, errInvalidGMLLinearRing
+				}
+				switch child.XMLName.Local {
+				case "exterior", "outerBoundaryIs":
+					exterior = coords
+				default:
+					interiors = append(interiors, coords)
+				}
+			}
+		}
+		if exterior == nil {
+			return geos.NewEmptyPolygon(), nil
+		}
+		return geos.NewPolygon(append([][][]float64{exterior}, interiors...)), nil
+	case "MultiPoint":
+		return gmlDecodeCollection(node, srs, geos.TypeIDMultiPoint)
+	case "MultiCurve", "MultiLineString":
+		return gmlDecodeCollection(node, srs, geos.TypeIDMultiLineString)
+	case "MultiSurface", "MultiPolygon":
+		return gmlDecodeCollection(node, srs, geos.TypeIDMultiPolygon)
+	case "MultiGeometry":
+		return gmlDecodeCollection(node, srs, geos.TypeIDGeometryCollection)
+	default:
+		return nil, fmt.Errorf("unsupported type: %s", node.XMLName.Local)
+	}
+}
+
+// gmlDecodeCollection decodes the geometries in the member elements of node.
+func gmlDecodeCollection(node *xmlNode, srs gmlSRS, typeID geos.TypeID) (*geos.Geom, error) {
+	var geoms []*geos.Geom
+	for i := range node.Children {
+		member := &node.Children[i]
+		switch member.XMLName.Local {
+		case "pointMember", "pointMembers", "curveMember", "curveMembers", "lineStringMember",
+			"surfaceMember", "surfaceMembers", "polygonMember", "geometryMember", "geometryMembers":
+		default:
+			continue
+		}
+		memberSRS, err := gmlParseSRS(member, srs)
+		if err != nil {
+			return nil, err
+		}
+		for j := range member.Children {
+			geom, err := gmlDecodeGeom(&member.Children[j], memberSRS)
+			if err != nil {
+				return nil, err
+			}
+			if memberTypeID, ok := gmlMemberTypeIDs[typeID]; ok && geom.TypeID() != memberTypeID {
+				return nil, fmt.Errorf("%s in %s: %w", geom.Type(), node.XMLName.Local, errInvalidGMLMember)
+			}
+			geoms = append(geoms, geom)
+		}
+	}
+	return geos.NewCollection(typeID, geoms), nil
+}
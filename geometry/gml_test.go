@@ -0,0 +1,156 @@
+package geometry_test
+
+import (
+	"encoding/xml"
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+
+	"github.com/twpayne/go-geos/geometry"
+)
+
+var (
+	_ xml.Marshaler   = &geometry.GMLGeometry{}
+	_ xml.Unmarshaler = &geometry.GMLGeometry{}
+)
+
+func TestGML(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		wkt  string
+		srid int
+		gml  string
+	}{
+		{
+			name: "point",
+			wkt:  "POINT (1 2)",
+			gml:  `<gml:Point xmlns:gml="http://www.opengis.net/gml/3.2"><gml:pos>1 2</gml:pos></gml:Point>`,
+		},
+		{
+			name: "point_epsg_4326",
+			wkt:  "POINT (1 2)",
+			srid: 4326,
+			gml:  `<gml:Point xmlns:gml="http://www.opengis.net/gml/3.2" srsName="urn:ogc:def:crs:EPSG::4326"><gml:pos>2 1</gml:pos></gml:Point>`,
+		},
+		{
+			name: "point_z",
+			wkt:  "POINT Z (1 2 3)",
+			srid: 3857,
+			gml:  `<gml:Point xmlns:gml="http://www.opengis.net/gml/3.2" srsName="urn:ogc:def:crs:EPSG::3857" srsDimension="3"><gml:pos>1 2 3</gml:pos></gml:Point>`,
+		},
+		{
+			name: "linestring",
+			wkt:  "LINESTRING (1 2, 3 4)",
+			gml:  `<gml:LineString xmlns:gml="http://www.opengis.net/gml/3.2"><gml:posList>1 2 3 4</gml:posList></gml:LineString>`,
+		},
+		{
+			name: "polygon",
+			wkt:  "POLYGON ((0 0, 3 0, 3 3, 0 3, 0 0), (1 1, 1 2, 2 2, 2 1, 1 1))",
+			gml: `<gml:Polygon xmlns:gml="http://www.opengis.net/gml/3.2">` +
+				`<gml:exterior><gml:LinearRing><gml:posList>0 0 3 0 3 3 0 3 0 0</gml:posList></gml:LinearRing></gml:exterior>` +
+				`<gml:interior><gml:LinearRing><gml:posList>1 1 1 2 2 2 2 1 1 1</gml:posList></gml:LinearRing></gml:interior>` +
+				`</gml:Polygon>`,
+		},
+		{
+			name: "multipoint",
+			wkt:  "MULTIPOINT ((1 2), (3 4))",
+			gml: `<gml:MultiPoint xmlns:gml="http://www.opengis.net/gml/3.2">` +
+				`<gml:pointMember><gml:Point><gml:pos>1 2</gml:pos></gml:Point></gml:pointMember>` +
+				`<gml:pointMember><gml:Point><gml:pos>3 4</gml:pos></gml:Point></gml:pointMember>` +
+				`</gml:MultiPoint>`,
+		},
+		{
+			name: "multilinestring",
+			wkt:  "MULTILINESTRING ((1 2, 3 4))",
+			srid: 4326,
+			gml: `<gml:MultiCurve xmlns:gml="http://www.opengis.net/gml/3.2" srsName="urn:ogc:def:crs:EPSG::4326">` +
+				`<gml:curveMember><gml:LineString><gml:posList>2 1 4 3</gml:posList></gml:LineString></gml:curveMember>` +
+				`</gml:MultiCurve>`,
+		},
+		{
+			name: "geometrycollection",
+			wkt:  "GEOMETRYCOLLECTION (POINT (1 2), MULTIPOLYGON (((0 0, 1 0, 1 1, 0 0))))",
+			gml: `<gml:MultiGeometry xmlns:gml="http://www.opengis.net/gml/3.2">` +
+				`<gml:geometryMember><gml:Point><gml:pos>1 2</gml:pos></gml:Point></gml:geometryMember>` +
+				`<gml:geometryMember><gml:MultiSurface><gml:surfaceMember><gml:Polygon>` +
+				`<gml:exterior><gml:LinearRing><gml:posList>0 0 1 0 1 1 0 0</gml:posList></gml:LinearRing></gml:exterior>` +
+				`</gml:Polygon></gml:surfaceMember></gml:MultiSurface></gml:geometryMember>` +
+				`</gml:MultiGeometry>`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			g := mustNewGeometryFromWKT(t, tc.wkt).SetSRID(tc.srid)
+			actualGML, err := g.AsGML()
+			assert.NoError(t, err)
+			assert.Equal(t, tc.gml, string(actualGML))
+
+			actual, err := geometry.NewGeometryFromGML([]byte(tc.gml))
+			assert.NoError(t, err)
+			assert.True(t, g.EqualsIdentical(actual.Geom))
+			assert.Equal(t, tc.srid, actual.SRID())
+		})
+	}
+}
+
+func TestGMLUnmarshal(t *testing.T) {
+	for _, tc := range []struct {
+		name         string
+		gml          string
+		expectedWKT  string
+		expectedSRID int
+	}{
+		{
+			name:         "legacy_epsg_4326_lon_lat",
+			gml:          `<gml:Point xmlns:gml="http://www.opengis.net/gml/3.2" srsName="EPSG:4326"><gml:pos>1 2</gml:pos></gml:Point>`,
+			expectedWKT:  "POINT (1 2)",
+			expectedSRID: 4326,
+		},
+		{
+			name:         "url_epsg_4326_lat_lon",
+			gml:          `<gml:Point xmlns:gml="http://www.opengis.net/gml/3.2" srsName="http://www.opengis.net/def/crs/EPSG/0/4326"><gml:pos>2 1</gml:pos></gml:Point>`,
+			expectedWKT:  "POINT (1 2)",
+			expectedSRID: 4326,
+		},
+		{
+			name:        "pos_list_srs_dimension",
+			gml:         `<gml:LineString xmlns:gml="http://www.opengis.net/gml/3.2"><gml:posList srsDimension="3">1 2 3 4 5 6</gml:posList></gml:LineString>`,
+			expectedWKT: "LINESTRING Z (1 2 3, 4 5 6)",
+		},
+		{
+			name:        "pos_children",
+			gml:         `<gml:LineString xmlns:gml="http://www.opengis.net/gml/3.2"><gml:pos>1 2</gml:pos><gml:pos>3 4</gml:pos></gml:LineString>`,
+			expectedWKT: "LINESTRING (1 2, 3 4)",
+		},
+		{
+			name:        "gml2_coordinates",
+			gml:         `<gml:Point xmlns:gml="http://www.opengis.net/gml"><gml:coordinates>1,2</gml:coordinates></gml:Point>`,
+			expectedWKT: "POINT (1 2)",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := geometry.NewGeometryFromGML([]byte(tc.gml))
+			assert.NoError(t, err)
+			assert.True(t, mustNewGeometryFromWKT(t, tc.expectedWKT).EqualsIdentical(actual.Geom))
+			assert.Equal(t, tc.expectedSRID, actual.SRID())
+		})
+	}
+}
+
+func TestGMLUnmarshalErrors(t *testing.T) {
+	for _, gml := range []string{
+		`<gml:Point xmlns:gml="http://www.opengis.net/gml/3.2"><gml:pos>1 2 3</gml:pos></gml:Point>`,
+		`<gml:Point xmlns:gml="http://www.opengis.net/gml/3.2" srsDimension="5"><gml:pos>1 2</gml:pos></gml:Point>`,
+		`<gml:Curve xmlns:gml="http://www.opengis.net/gml/3.2"></gml:Curve>`,
+		`<gml:LineString xmlns:gml="http://www.opengis.net/gml/3.2"><gml:posList>1 2</gml:posList></gml:LineString>`,
+		`<gml:LinearRing xmlns:gml="http://www.opengis.net/gml/3.2"><gml:posList>0 0 1 0 1 1 0 1</gml:posList></gml:LinearRing>`,
+		`<gml:Polygon xmlns:gml="http://www.opengis.net/gml/3.2"><gml:exterior><gml:LinearRing><gml:posList>0 0 1 0 1 1 0 1</gml:posList></gml:LinearRing></gml:exterior></gml:Polygon>`,
+		`<gml:Polygon xmlns:gml="http://www.opengis.net/gml/3.2"><gml:exterior><gml:LinearRing><gml:posList>0 0 1 0 0 0</gml:posList></gml:LinearRing></gml:exterior></gml:Polygon>`,
+		`<gml:MultiCurve xmlns:gml="http://www.opengis.net/gml/3.2"><gml:curveMember><gml:LineString><gml:posList>1 2</gml:posList></gml:LineString></gml:curveMember></gml:MultiCurve>`,
+		`<gml:MultiPoint xmlns:gml="http://www.opengis.net/gml/3.2"><gml:pointMember><gml:LineString><gml:posList>1 2 3 4</gml:posList></gml:LineString></gml:pointMember></gml:MultiPoint>`,
+		`<gml:MultiCurve xmlns:gml="http://www.opengis.net/gml/3.2"><gml:curveMember><gml:Point><gml:pos>1 2</gml:pos></gml:Point></gml:curveMember></gml:MultiCurve>`,
+		`<gml:MultiSurface xmlns:gml="http://www.opengis.net/gml/3.2"><gml:surfaceMember><gml:MultiSurface></gml:MultiSurface></gml:surfaceMember></gml:MultiSurface>`,
+	} {
+		_, err := geometry.NewGeometryFromGML([]byte(gml))
+		assert.Error(t, err)
+	}
+}
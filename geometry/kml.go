@@ -2,6 +2,7 @@ package geometry
 
 import (
 	"encoding/xml"
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
@@ -9,6 +10,9 @@ import (
 	"github.com/twpayne/go-geos"
 )
 
+// kmlGXNamespace is the Google KML extensions namespace.
+const kmlGXNamespace = "http://www.google.com/kml/ext/2.2"
+
 var (
 	kmlPointStartElement           = xml.StartElement{Name: xml.Name{Local: "Point"}}
 	kmlLineStringStartElement      = xml.StartElement{Name: xml.Name{Local: "LineString"}}
@@ -18,17 +22,269 @@ var (
 	kmlCoordinatesStartElement     = xml.StartElement{Name: xml.Name{Local: "coordinates"}}
 	kmlInnerBoundaryIsStartElement = xml.StartElement{Name: xml.Name{Local: "innerBoundaryIs"}}
 	kmlOuterBoundaryIsStartElement = xml.StartElement{Name: xml.Name{Local: "outerBoundaryIs"}}
+	kmlExtrudeStartElement         = xml.StartElement{Name: xml.Name{Local: "extrude"}}
+	kmlAltitudeModeStartElement    = xml.StartElement{Name: xml.Name{Local: "altitudeMode"}}
+	kmlGXAltitudeModeStartElement  = xml.StartElement{
+		Name: xml.Name{Local: "gx:altitudeMode"},
+		Attr: []xml.Attr{
+			{Name: xml.Name{Local: "xmlns:gx"}, Value: kmlGXNamespace},
+		},
+	}
+
+	// kmlGXAltitudeModes are the altitude modes that are only valid in the
+	// gx:altitudeMode element.
+	kmlGXAltitudeModes = map[string]bool{
+		"clampToSeaFloor":    true,
+		"relativeToSeaFloor": true,
+	}
+
+	errInvalidKMLLinearRing = errors.New("invalid KML LinearRing")
+	errInvalidKMLLineString = errors.New("invalid KML LineString")
 )
 
+// A KMLGeometry is a Geometry with KML's altitudeMode and extrude elements.
+// They apply to every Point, LineString, LinearRing, and Polygon in the
+// geometry. The AltitudeModes clampToSeaFloor and relativeToSeaFloor are
+// written in gx:altitudeMode elements, as KML 2.2 does not allow them in
+// altitudeMode elements.
+type KMLGeometry struct {
+	Geometry
+	AltitudeMode string
+	Extrude      bool
+}
+
+// NewGeometryFromKML returns a new Geometry from the KML geometry element kml.
+func NewGeometryFromKML(kml []byte) (*Geometry, error) {
+	var g Geometry
+	if err := xml.Unmarshal(kml, &g); err != nil {
+		return nil, err
+	}
+	return &g, nil
+}
+
 // MarshalXML implements encoding/xml.Marshaler.
 func (g *Geometry) MarshalXML(e *xml.Encoder, _ xml.StartElement) error {
-	return kmlEncodeGeom(e, g.Geom)
+	return kmlEncodeGeom(e, g.Geom, nil)
+}
+
+// UnmarshalXML implements encoding/xml.Unmarshaler. altitudeMode and extrude
+// elements are ignored.
+func (g *Geometry) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var kmlGeometry KMLGeometry
+	if err := kmlGeometry.UnmarshalXML(d, start); err != nil {
+		return err
+	}
+	*g = kmlGeometry.Geometry
+	return nil
+}
+
+// MarshalXML implements encoding/xml.Marshaler.
+func (g *KMLGeometry) MarshalXML(e *xml.Encoder, _ xml.StartElement) error {
+	return kmlEncodeGeom(e, g.Geom, g.encodeAttrs)
+}
+
+// UnmarshalXML implements encoding/xml.Unmarshaler.
+func (g *KMLGeometry) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var node xmlNode
+	if err := d.DecodeElement(&node, &start); err != nil {
+		return err
+	}
+	geom, err := g.decodeGeom(&node)
+	if err != nil {
+		return err
+	}
+	g.Geom = geom
+	return nil
 }
 
-func kmlEncodeCoords(e *xml.Encoder, startElement xml.StartElement, geom *geos.Geom) error {
+// encodeAttrs encodes g's altitudeMode and extrude elements.
+func (g *KMLGeometry) encodeAttrs(e *xml.Encoder) error {
+	if g.Extrude {
+		if err := kmlEncodeSimpleElement(e, kmlExtrudeStartElement, "1"); err != nil {
+			return err
+		}
+	}
+	switch {
+	case g.AltitudeMode == "":
+	case kmlGXAltitudeModes[g.AltitudeMode]:
+		if err := kmlEncodeSimpleElement(e, kmlGXAltitudeModeStartElement, g.AltitudeMode); err != nil {
+			return err
+		}
+	default:
+		if err := kmlEncodeSimpleElement(e, kmlAltitudeModeStartElement, g.AltitudeMode); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// decodeGeom decodes the geometry in node, setting g's AltitudeMode and
+// Extrude from the first geometry that has them. AltitudeMode is read from
+// both altitudeMode and gx:altitudeMode elements.
+func (g *KMLGeometry) decodeGeom(node *xmlNode) (*geos.Geom, error) {
+	for i := range node.Children {
+		child := &node.Children[i]
+		switch child.XMLName.Local {
+		case "altitudeMode":
+			if g.AltitudeMode == "" {
+				g.AltitudeMode = strings.TrimSpace(child.CharData)
+			}
+		case "extrude":
+			extrude, err := strconv.ParseBool(strings.TrimSpace(child.CharData))
+			if err != nil {
+				return nil, err
+			}
+			g.Extrude = g.Extrude || extrude
+		}
+	}
+
+	switch node.XMLName.Local {
+	case "Point":
+		coords, err := kmlDecodeCoords(node)
+		switch {
+		case err != nil:
+			return nil, err
+		case len(coords) == 0:
+			return geos.NewEmptyPoint(), nil
+		default:
+			return geos.NewPoint(coords[0]), nil
+		}
+	case "LineString":
+		coords, err := kmlDecodeCoords(node)
+		switch {
+		case err != nil:
+			return nil, err
+		case len(coords) == 0:
+			return geos.NewEmptyLineString(), nil
+		case !isLineStringCoords(coords):
+			return nil, errInvalidKMLLineString
+		default:
+			return geos.NewLineString(coords), nil
+		}
+	case "LinearRing":
+		coords, err := kmlDecodeCoords(node)
+		switch {
+		case err != nil:
+			return nil, err
+		case len(coords) == 0:
+			return geos.NewGeomFromWKT("LINEARRING EMPTY")
+		case !isLinearRingCoords(coords):
+			return nil, errInvalidKMLLinearRing
+		default:
+			return geos.NewLinearRing(coords), nil
+		}
+	case "Polygon":
+		var exterior [][]float64
+		var interiors [][][]float64
+		for i := range node.Children {
+			child := &node.Children[i]
+			switch child.XMLName.Local {
+			case "outerBoundaryIs", "innerBoundaryIs":
+			default:
+				continue
+			}
+			for j := range child.Children {
+				ring := &child.Children[j]
+				if ring.XMLName.Local != "LinearRing" {
+					continue
+				}
+				coords, err := kmlDecodeCoords(ring)
+				if err != nil {
+					return nil, err
+				}
+				if !isLinearRingCoords(coords) {
+					return nil, errInvalidKMLLinearRing
+				}
+				if child.XMLName.Local == "outerBoundaryIs" {
+					exterior = coords
+				} else {
+					interiors = append(interiors, coords)
+				}
+			}
+		}
+		if exterior == nil {
+			return geos.NewEmptyPolygon(), nil
+		}
+		return geos.NewPolygon(append([][][]float64{exterior}, interiors...)), nil
+	case "MultiGeometry":
+		var geoms []*geos.Geom
+		for i := range node.Children {
+			child := &node.Children[i]
+			switch child.XMLName.Local {
+			case "Point", "LineString", "LinearRing", "Polygon", "MultiGeometry":
+			default:
+				continue
+			}
+			geom, err := g.decodeGeom(child)
+			if err != nil {
+				return nil, err
+			}
+			geoms = append(geoms, geom)
+		}
+		return geos.NewCollection(kmlMultiGeometryTypeID(geoms), geoms), nil
+	default:
+		return nil, fmt.Errorf("unsupported type: %s", node.XMLName.Local)
+	}
+}
+
+func kmlDecodeCoords(node *xmlNode) ([][]float64, error) {
+	var coords [][]float64
+	for i := range node.Children {
+		child := &node.Children[i]
+		if child.XMLName.Local != "coordinates" {
+			continue
+		}
+		for _, tuple := range strings.Fields(child.CharData) {
+			ords := strings.Split(tuple, ",")
+			if len(ords) < 2 || 3 < len(ords) || (len(coords) > 0 && len(ords) != len(coords[0])) {
+				return nil, fmt.Errorf("%s: invalid coordinates", tuple)
+			}
+			coord := make([]float64, len(ords))
+			for j, ord := range ords {
+				var err error
+				if coord[j], err = strconv.ParseFloat(ord, 64); err != nil {
+					return nil, err
+				}
+			}
+			coords = append(coords, coord)
+		}
+	}
+	return coords, nil
+}
+
+// kmlMultiGeometryTypeID returns the type of a MultiGeometry containing geoms.
+func kmlMultiGeometryTypeID(geoms []*geos.Geom) geos.TypeID {
+	if len(geoms) == 0 {
+		return geos.TypeIDGeometryCollection
+	}
+	var typeID geos.TypeID
+	switch geoms[0].TypeID() {
+	case geos.TypeIDPoint:
+		typeID = geos.TypeIDMultiPoint
+	case geos.TypeIDLineString:
+		typeID = geos.TypeIDMultiLineString
+	case geos.TypeIDPolygon:
+		typeID = geos.TypeIDMultiPolygon
+	default:
+		return geos.TypeIDGeometryCollection
+	}
+	for _, geom := range geoms[1:] {
+		if geom.TypeID() != geoms[0].TypeID() {
+			return geos.TypeIDGeometryCollection
+		}
+	}
+	return typeID
+}
+
+func kmlEncodeCoords(e *xml.Encoder, startElement xml.StartElement, geom *geos.Geom, encodeAttrs func(*xml.Encoder) error) error {
 	if err := e.EncodeToken(startElement); err != nil {
 		return err
 	}
+	if encodeAttrs != nil {
+		if err := encodeAttrs(e); err != nil {
+			return err
+		}
+	}
 	if coords := geom.CoordSeq().ToCoords(); coords != nil {
 		if err := e.EncodeToken(kmlCoordinatesStartElement); err != nil {
 			return err
@@ -62,18 +318,18 @@ func kmlEncodeCoords(e *xml.Encoder, startElement xml.StartElement, geom *geos.G
 	return e.EncodeToken(startElement.End())
 }
 
-func kmlEncodeGeom(e *xml.Encoder, geom *geos.Geom) error {
+func kmlEncodeGeom(e *xml.Encoder, geom *geos.Geom, encodeAttrs func(*xml.Encoder) error) error {
 	switch geom.TypeID() {
 	case geos.TypeIDPoint:
-		return kmlEncodeCoords(e, kmlPointStartElement, geom)
+		return kmlEncodeCoords(e, kmlPointStartElement, geom, encodeAttrs)
 	case geos.TypeIDLineString:
-		return kmlEncodeCoords(e, kmlLineStringStartElement, geom)
+		return kmlEncodeCoords(e, kmlLineStringStartElement, geom, encodeAttrs)
 	case geos.TypeIDLinearRing:
-		return kmlEncodeCoords(e, kmlLinearRingStartElement, geom)
+		return kmlEncodeCoords(e, kmlLinearRingStartElement, geom, encodeAttrs)
 	case geos.TypeIDPolygon:
-		return kmlEncodePolygon(e, geom)
+		return kmlEncodePolygon(e, geom, encodeAttrs)
 	case geos.TypeIDMultiPoint, geos.TypeIDMultiLineString, geos.TypeIDMultiPolygon, geos.TypeIDGeometryCollection:
-		return kmlEncodeMultiGeometry(e, geom)
+		return kmlEncodeMultiGeometry(e, geom, encodeAttrs)
 	default:
 		return fmt.Errorf("unsupported type: %s", geom.Type())
 	}
@@ -83,28 +339,33 @@ func kmlEncodeLinearRing(e *xml.Encoder, startElement xml.StartElement, geom *ge
 	if err := e.EncodeToken(startElement); err != nil {
 		return err
 	}
-	if err := kmlEncodeCoords(e, kmlLinearRingStartElement, geom); err != nil {
+	if err := kmlEncodeCoords(e, kmlLinearRingStartElement, geom, nil); err != nil {
 		return err
 	}
 	return e.EncodeToken(startElement.End())
 }
 
-func kmlEncodeMultiGeometry(e *xml.Encoder, geom *geos.Geom) error {
+func kmlEncodeMultiGeometry(e *xml.Encoder, geom *geos.Geom, encodeAttrs func(*xml.Encoder) error) error {
 	if err := e.EncodeToken(kmlMultiGeometryStartElement); err != nil {
 		return err
 	}
 	for i, n := 0, geom.NumGeometries(); i < n; i++ {
-		if err := kmlEncodeGeom(e, geom.Geometry(i)); err != nil {
+		if err := kmlEncodeGeom(e, geom.Geometry(i), encodeAttrs); err != nil {
 			return err
 		}
 	}
 	return e.EncodeToken(kmlMultiGeometryStartElement.End())
 }
 
-func kmlEncodePolygon(e *xml.Encoder, geom *geos.Geom) error {
+func kmlEncodePolygon(e *xml.Encoder, geom *geos.Geom, encodeAttrs func(*xml.Encoder) error) error {
 	if err := e.EncodeToken(kmlPolygonStartElement); err != nil {
 		return err
 	}
+	if encodeAttrs != nil {
+		if err := encodeAttrs(e); err != nil {
+			return err
+		}
+	}
 	if !geom.IsEmpty() {
 		if err := kmlEncodeLinearRing(e, kmlOuterBoundaryIsStartElement, geom.ExteriorRing()); err != nil {
 			return err
@@ -117,3 +378,13 @@ func kmlEncodePolygon(e *xml.Encoder, geom *geos.Geom) error {
 	}
 	return e.EncodeToken(kmlPolygonStartElement.End())
 }
+
+func kmlEncodeSimpleElement(e *xml.Encoder, startElement xml.StartElement, value string) error {
+	if err := e.EncodeToken(startElement); err != nil {
+		return err
+	}
+	if err := e.EncodeToken(xml.CharData(value)); err != nil {
+		return err
+	}
+	return e.EncodeToken(startElement.End())
+}
@@ -2,8 +2,158 @@ package geometry_test
 
 import (
 	"encoding/xml"
+	"strings"
+	"testing"
 
+	"github.com/alecthomas/assert/v2"
+
+	"github.com/twpayne/go-geos"
 	"github.com/twpayne/go-geos/geometry"
 )
 
-var _ xml.Marshaler = &geometry.Geometry{}
+var (
+	_ xml.Marshaler   = &geometry.Geometry{}
+	_ xml.Marshaler   = &geometry.KMLGeometry{}
+	_ xml.Unmarshaler = &geometry.Geometry{}
+	_ xml.Unmarshaler = &geometry.KMLGeometry{}
+)
+
+func TestNewGeometryFromKML(t *testing.T) {
+	for _, tc := range []struct {
+		name          string
+		kml           string
+		expectedWKT   string
+		expectedError bool
+	}{
+		{
+			name:        "point",
+			kml:         "<Point><coordinates>0,1</coordinates></Point>",
+			expectedWKT: "POINT (0 1)",
+		},
+		{
+			name:        "point_z",
+			kml:         "<Point><extrude>1</extrude><coordinates> 0,1,2 </coordinates></Point>",
+			expectedWKT: "POINT Z (0 1 2)",
+		},
+		{
+			name:        "linestring_whitespace",
+			kml:         "<LineString><tessellate>1</tessellate><coordinates>\n\t0,1\n\t2,3\n</coordinates></LineString>",
+			expectedWKT: "LINESTRING (0 1, 2 3)",
+		},
+		{
+			name: "polygon_interior_rings",
+			kml: "" +
+				"<Polygon>" +
+				"<outerBoundaryIs><LinearRing><coordinates>0,0 3,0 3,3 0,3 0,0</coordinates></LinearRing></outerBoundaryIs>" +
+				"<innerBoundaryIs><LinearRing><coordinates>1,1 1,2 2,2 2,1 1,1</coordinates></LinearRing></innerBoundaryIs>" +
+				"</Polygon>",
+			expectedWKT: "POLYGON ((0 0, 3 0, 3 3, 0 3, 0 0), (1 1, 1 2, 2 2, 2 1, 1 1))",
+		},
+		{
+			name: "multigeometry_heterogeneous",
+			kml: "" +
+				"<MultiGeometry>" +
+				"<Point><coordinates>0,1</coordinates></Point>" +
+				"<LineString><coordinates>2,3 4,5</coordinates></LineString>" +
+				"</MultiGeometry>",
+			expectedWKT: "GEOMETRYCOLLECTION (POINT (0 1), LINESTRING (2 3, 4 5))",
+		},
+		{
+			name:          "invalid_coordinates",
+			kml:           "<Point><coordinates>0</coordinates></Point>",
+			expectedError: true,
+		},
+		{
+			name:          "inconsistent_dimensions",
+			kml:           "<LineString><coordinates>0,1 2,3,4</coordinates></LineString>",
+			expectedError: true,
+		},
+		{
+			name:          "linestring_one_coordinate",
+			kml:           "<LineString><coordinates>0,1</coordinates></LineString>",
+			expectedError: true,
+		},
+		{
+			name:          "linearring_unclosed",
+			kml:           "<LinearRing><coordinates>0,0 1,0 1,1 0,1</coordinates></LinearRing>",
+			expectedError: true,
+		},
+		{
+			name:          "polygon_unclosed",
+			kml:           "<Polygon><outerBoundaryIs><LinearRing><coordinates>0,0 1,0 1,1 0,1</coordinates></LinearRing></outerBoundaryIs></Polygon>",
+			expectedError: true,
+		},
+		{
+			name:          "polygon_interior_too_short",
+			kml:           "<Polygon><outerBoundaryIs><LinearRing><coordinates>0,0 3,0 3,3 0,0</coordinates></LinearRing></outerBoundaryIs><innerBoundaryIs><LinearRing><coordinates>1,1 2,1 1,1</coordinates></LinearRing></innerBoundaryIs></Polygon>",
+			expectedError: true,
+		},
+		{
+			name:          "unsupported_type",
+			kml:           "<Model></Model>",
+			expectedError: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := geometry.NewGeometryFromKML([]byte(tc.kml))
+			if tc.expectedError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.True(t, actual.Equals(mustNewGeometryFromWKT(t, tc.expectedWKT).Geom))
+		})
+	}
+}
+
+func TestKMLGeometry(t *testing.T) {
+	g := &geometry.KMLGeometry{
+		Geometry: *geometry.NewGeometry(geos.NewCollection(geos.TypeIDMultiPolygon, []*geos.Geom{
+			mustNewGeometryFromWKT(t, "POLYGON Z ((0 0 1, 1 0 1, 1 1 1, 0 0 1))").Geom,
+		})),
+		AltitudeMode: "relativeToGround",
+		Extrude:      true,
+	}
+	data := &strings.Builder{}
+	assert.NoError(t, xml.NewEncoder(data).Encode(g))
+	assert.Equal(t, ""+
+		"<MultiGeometry>"+
+		"<Polygon>"+
+		"<extrude>1</extrude>"+
+		"<altitudeMode>relativeToGround</altitudeMode>"+
+		"<outerBoundaryIs><LinearRing><coordinates>0,0,1 1,0,1 1,1,1 0,0,1</coordinates></LinearRing></outerBoundaryIs>"+
+		"</Polygon>"+
+		"</MultiGeometry>",
+		data.String(),
+	)
+
+	var actual geometry.KMLGeometry
+	assert.NoError(t, xml.Unmarshal([]byte(data.String()), &actual))
+	assert.Equal(t, "relativeToGround", actual.AltitudeMode)
+	assert.True(t, actual.Extrude)
+	assert.Equal(t, geos.TypeIDMultiPolygon, actual.TypeID())
+	assert.True(t, actual.Equals(g.Geom))
+}
+
+func TestKMLGeometryGXAltitudeMode(t *testing.T) {
+	var actual geometry.KMLGeometry
+	assert.NoError(t, xml.Unmarshal([]byte(""+
+		`<Point xmlns:gx="http://www.google.com/kml/ext/2.2">`+
+		"<gx:altitudeMode>clampToSeaFloor</gx:altitudeMode>"+
+		"<coordinates>0,1,-2</coordinates>"+
+		"</Point>",
+	), &actual))
+	assert.Equal(t, "clampToSeaFloor", actual.AltitudeMode)
+	assert.False(t, actual.Extrude)
+	assert.True(t, actual.Equals(mustNewGeometryFromWKT(t, "POINT Z (0 1 -2)").Geom))
+
+	data := &strings.Builder{}
+	assert.NoError(t, xml.NewEncoder(data).Encode(&actual))
+	assert.Equal(t, ""+
+		"<Point>"+
+		`<gx:altitudeMode xmlns:gx="http://www.google.com/kml/ext/2.2">clampToSeaFloor</gx:altitudeMode>`+
+		"<coordinates>0,1,-2</coordinates>"+
+		"</Point>",
+		data.String(),
+	)
+}
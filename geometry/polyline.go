@@ -0,0 +1,150 @@
+package geometry
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/twpayne/go-geos"
+)
+
+// maxPolylinePrecision is the maximum supported polyline precision.
+const maxPolylinePrecision = 10
+
+var (
+	errInvalidPolyline             = errors.New("invalid polyline")
+	errPolylineOnePoint            = errors.New("polyline has only one point")
+	errPolylinePrecisionOutOfRange = errors.New("polyline precision out of range")
+)
+
+// NewGeometryFromPolyline returns a new LineString from the encoded polyline s
+// with precision decimal places, typically 5 (as used by Google) or 6 (as used
+// by OSRM and Valhalla). Polylines always encode latitude before longitude,
+// and the returned coordinates are always longitude, latitude; no other axis
+// order is supported. A polyline with a single point is an error, as it is not
+// a valid LineString.
+func NewGeometryFromPolyline(s string, precision int) (*Geometry, error) {
+	coords, err := polylineDecode(s, precision)
+	if err != nil {
+		return nil, err
+	}
+	switch len(coords) {
+	case 0:
+		return &Geometry{Geom: geos.NewEmptyLineString()}, nil
+	case 1:
+		return nil, errPolylineOnePoint
+	}
+	return &Geometry{Geom: geos.NewLineString(coords)}, nil
+}
+
+// NewGeometryFromPolylines returns a new MultiLineString from the encoded
+// polylines ss with precision decimal places.
+func NewGeometryFromPolylines(ss []string, precision int) (*Geometry, error) {
+	geoms := make([]*geos.Geom, 0, len(ss))
+	for _, s := range ss {
+		g, err := NewGeometryFromPolyline(s, precision)
+		if err != nil {
+			return nil, err
+		}
+		geoms = append(geoms, g.Geom)
+	}
+	return &Geometry{Geom: geos.NewCollection(geos.TypeIDMultiLineString, geoms)}, nil
+}
+
+// AsPolyline returns g, which must be a LineString, as an encoded polyline with
+// precision decimal places. g's coordinates must be longitude, latitude.
+func (g *Geometry) AsPolyline(precision int) (string, error) {
+	if g.TypeID() != geos.TypeIDLineString {
+		return "", fmt.Errorf("unsupported type: %s", g.Type())
+	}
+	return polylineEncode(g.Geom, precision)
+}
+
+// AsPolylines returns g, which must be a LineString or a MultiLineString, as
+// an array of encoded polylines with precision decimal places.
+func (g *Geometry) AsPolylines(precision int) ([]string, error) {
+	switch g.TypeID() {
+	case geos.TypeIDLineString:
+		s, err := polylineEncode(g.Geom, precision)
+		if err != nil {
+			return nil, err
+		}
+		return []string{s}, nil
+	case geos.TypeIDMultiLineString:
+		n := g.NumGeometries()
+		ss := make([]string, 0, n)
+		for i := range n {
+			s, err := polylineEncode(g.Geometry(i), precision)
+			if err != nil {
+				return nil, err
+			}
+			ss = append(ss, s)
+		}
+		return ss, nil
+	default:
+		return nil, fmt.Errorf("unsupported type: %s", g.Type())
+	}
+}
+
+func polylineDecode(s string, precision int) ([][]float64, error) {
+	if precision < 0 || maxPolylinePrecision < precision {
+		return nil, fmt.Errorf("%d: %w", precision, errPolylinePrecisionOutOfRange)
+	}
+	scale := math.Pow10(precision)
+	var coords [][]float64
+	var lat, lon int64
+	for i := 0; i < len(s); {
+		var deltas [2]int64
+		for j := range deltas {
+			var value uint64
+			var shift uint
+			for {
+				if i >= len(s) || s[i] < 63 || 126 < s[i] || shift > 63 {
+					return nil, errInvalidPolyline
+				}
+				chunk := uint64(s[i] - 63)
+				i++
+				value |= (chunk & 0x1f) << shift
+				shift += 5
+				if chunk&0x20 == 0 {
+					break
+				}
+			}
+			deltas[j] = int64(value>>1) ^ -int64(value&1)
+		}
+		lat += deltas[0]
+		lon += deltas[1]
+		coords = append(coords, []float64{float64(lon) / scale, float64(lat) / scale})
+	}
+	return coords, nil
+}
+
+func polylineEncode(geom *geos.Geom, precision int) (string, error) {
+	if precision < 0 || maxPolylinePrecision < precision {
+		return "", fmt.Errorf("%d: %w", precision, errPolylinePrecisionOutOfRange)
+	}
+	scale := math.Pow10(precision)
+	sb := &strings.Builder{}
+	var prevLat, prevLon int64
+	for _, coord := range geom.CoordSeq().ToCoords() {
+		lat := int64(math.Round(coord[1] * scale))
+		lon := int64(math.Round(coord[0] * scale))
+		polylineWriteValue(sb, lat-prevLat)
+		polylineWriteValue(sb, lon-prevLon)
+		prevLat, prevLon = lat, lon
+	}
+	return sb.String(), nil
+}
+
+func polylineWriteValue(sb *strings.Builder, value int64) {
+	u := uint64(value) << 1
+	if value < 0 {
+		u = ^u
+	}
+	for u >= 0x20 {
+		sb.WriteByte(byte(0x20|u&0x1f) + 63)
+		u >>= 5
+	}
+	sb.WriteByte(byte(u) + 63)
+}
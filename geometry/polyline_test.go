@@ -0,0 +1,77 @@
+package geometry_test
+
+import (
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+
+	"github.com/twpayne/go-geos/geometry"
+)
+
+func TestPolyline(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		wkt       string
+		precision int
+		polyline  string
+	}{
+		{
+			name:      "google_example",
+			wkt:       "LINESTRING (-120.2 38.5, -120.95 40.7, -126.453 43.252)",
+			precision: 5,
+			polyline:  "_p~iF~ps|U_ulLnnqC_mqNvxq`@",
+		},
+		{
+			name:      "google_example_precision_6",
+			wkt:       "LINESTRING (-12.02 3.85, -12.095 4.07, -12.6453 4.3252)",
+			precision: 6,
+			polyline:  "_p~iF~ps|U_ulLnnqC_mqNvxq`@",
+		},
+		{
+			name:      "empty",
+			wkt:       "LINESTRING EMPTY",
+			precision: 5,
+			polyline:  "",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			g := mustNewGeometryFromWKT(t, tc.wkt)
+			actualPolyline, err := g.AsPolyline(tc.precision)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.polyline, actualPolyline)
+
+			actualGeometry, err := geometry.NewGeometryFromPolyline(tc.polyline, tc.precision)
+			assert.NoError(t, err)
+			assert.True(t, g.EqualsExact(actualGeometry.Geom, 1e-9))
+		})
+	}
+}
+
+func TestPolylines(t *testing.T) {
+	g := mustNewGeometryFromWKT(t, "MULTILINESTRING ((-120.2 38.5, -120.95 40.7, -126.453 43.252), (0 0, 1 1))")
+	polylines, err := g.AsPolylines(5)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"_p~iF~ps|U_ulLnnqC_mqNvxq`@", "??_ibE_ibE"}, polylines)
+
+	actual, err := geometry.NewGeometryFromPolylines(polylines, 5)
+	assert.NoError(t, err)
+	assert.True(t, g.EqualsExact(actual.Geom, 1e-9))
+
+	_, err = g.AsPolyline(5)
+	assert.Error(t, err)
+}
+
+func TestPolylineErrors(t *testing.T) {
+	_, err := geometry.NewGeometryFromPolyline("_p~iF~ps|U_", 5)
+	assert.Error(t, err)
+	_, err = geometry.NewGeometryFromPolyline("_p~iF", 5)
+	assert.Error(t, err)
+	_, err = geometry.NewGeometryFromPolyline("_p~iF~ps|U", 5)
+	assert.Error(t, err)
+	_, err = geometry.NewGeometryFromPolylines([]string{"_p~iF~ps|U_ulLnnqC", "_p~iF~ps|U"}, 5)
+	assert.Error(t, err)
+	_, err = geometry.NewGeometryFromPolyline("??", 11)
+	assert.Error(t, err)
+	_, err = mustNewGeometryFromWKT(t, "POINT (1 2)").AsPolyline(5)
+	assert.Error(t, err)
+}
@@ -45,5 +45,5 @@ func (g Geometry) Value() (driver.Value, error) {
 	if g.Geom == nil {
 		return nil, nil //nolint:nilnil
 	}
-	return hex.EncodeToString(g.ToEWKBWithSRID()), nil
+	return g.ToEWKBHexWithSRID(), nil
 }
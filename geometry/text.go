@@ -2,6 +2,16 @@ package geometry
 
 import "github.com/twpayne/go-geos"
 
+// NewGeometryFromEWKT returns a new Geometry from ewkt, which may include an
+// SRID prefix.
+func NewGeometryFromEWKT(ewkt string) (*Geometry, error) {
+	geom, err := geos.NewGeomFromEWKT(ewkt)
+	if err != nil {
+		return nil, err
+	}
+	return &Geometry{Geom: geom}, nil
+}
+
 // NewGeometryFromWKT returns a new Geometry from wkt.
 func NewGeometryFromWKT(wkt string) (*Geometry, error) {
 	geom, err := geos.NewGeomFromWKT(wkt)
@@ -11,6 +21,13 @@ func NewGeometryFromWKT(wkt string) (*Geometry, error) {
 	return &Geometry{Geom: geom}, nil
 }
 
+// An EWKTGeometry is a Geometry that is marshaled to text as EWKT, with an
+// SRID prefix if its SRID is non-zero, rather than WKT. All other encodings are
+// the same as Geometry's.
+type EWKTGeometry struct {
+	Geometry
+}
+
 // AppendText implements encoding.TextAppender.
 func (g *Geometry) AppendText(b []byte) ([]byte, error) {
 	return append(b, []byte(g.ToWKT())...), nil
@@ -21,12 +38,29 @@ func (g *Geometry) MarshalText() ([]byte, error) {
 	return []byte(g.ToWKT()), nil
 }
 
-// UnmarshalText implements encoding.TextUnmarshaler.
+// UnmarshalText implements encoding.TextUnmarshaler. It accepts both WKT and
+// EWKT.
 func (g *Geometry) UnmarshalText(data []byte) error {
-	geom, err := geos.NewGeomFromWKT(string(data))
+	geom, err := geos.NewGeomFromEWKT(string(data))
 	if err != nil {
 		return err
 	}
 	g.Geom = geom
 	return nil
 }
+
+// AppendText implements encoding.TextAppender.
+func (g *EWKTGeometry) AppendText(b []byte) ([]byte, error) {
+	return append(b, []byte(g.ToEWKT())...), nil
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (g *EWKTGeometry) MarshalText() ([]byte, error) {
+	return []byte(g.ToEWKT()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. It accepts both WKT and
+// EWKT.
+func (g *EWKTGeometry) UnmarshalText(data []byte) error {
+	return g.Geometry.UnmarshalText(data)
+}
@@ -2,6 +2,8 @@ package geometry_test
 
 import (
 	"encoding"
+	"encoding/json"
+	"runtime"
 	"strconv"
 	"testing"
 
@@ -12,8 +14,14 @@ import (
 )
 
 var (
+	_ encoding.TextAppender    = &geometry.Geometry{}
 	_ encoding.TextMarshaler   = &geometry.Geometry{}
 	_ encoding.TextUnmarshaler = &geometry.Geometry{}
+	_ encoding.TextAppender    = &geometry.EWKTGeometry{}
+	_ encoding.TextMarshaler   = &geometry.EWKTGeometry{}
+	_ encoding.TextUnmarshaler = &geometry.EWKTGeometry{}
+	_ json.Marshaler           = &geometry.EWKTGeometry{}
+	_ json.Unmarshaler         = &geometry.EWKTGeometry{}
 )
 
 func TestText(t *testing.T) {
@@ -36,6 +44,57 @@ func TestText(t *testing.T) {
 			var geom geometry.Geometry
 			assert.NoError(t, geom.UnmarshalText([]byte(textStr)))
 			assert.True(t, tc.geom.Equals(geom.Geom))
+			assert.Equal(t, tc.geom.SRID(), geom.SRID())
 		})
 	}
 }
+
+func TestEWKTText(t *testing.T) {
+	g := geometry.NewGeometry(geos.NewPoint([]float64{1, 2})).SetSRID(4326)
+
+	text, err := g.MarshalText()
+	assert.NoError(t, err)
+	assert.Equal(t, "POINT (1 2)", string(text))
+
+	ewktText, err := (&geometry.EWKTGeometry{Geometry: *g}).MarshalText()
+	assert.NoError(t, err)
+	assert.Equal(t, "SRID=4326;POINT (1 2)", string(ewktText))
+
+	ewktText, err = (&geometry.EWKTGeometry{Geometry: *g}).AppendText([]byte("geom="))
+	assert.NoError(t, err)
+	assert.Equal(t, "geom=SRID=4326;POINT (1 2)", string(ewktText))
+
+	var actual geometry.EWKTGeometry
+	assert.NoError(t, actual.UnmarshalText([]byte("SRID=4326;POINT (1 2)")))
+	assert.True(t, g.Equals(actual.Geom))
+	assert.Equal(t, 4326, actual.SRID())
+
+	var actualG geometry.Geometry
+	assert.NoError(t, actualG.UnmarshalText([]byte("SRID=4326;POINT (1 2)")))
+	assert.Equal(t, 4326, actualG.SRID())
+}
+
+func TestEWKTGeometryEncodings(t *testing.T) {
+	defer runtime.GC() // Exercise finalizers.
+	g := &geometry.EWKTGeometry{
+		Geometry: *geometry.NewGeometry(geos.NewPoint([]float64{1, 2})).SetSRID(4326),
+	}
+
+	t.Run("geojson", func(t *testing.T) {
+		data, err := json.Marshal(g)
+		assert.NoError(t, err)
+		assert.Equal(t, `{"type":"Point","coordinates":[1,2]}`, string(data))
+		var actual geometry.EWKTGeometry
+		assert.NoError(t, json.Unmarshal(data, &actual))
+		assert.True(t, actual.Equals(g.Geom))
+	})
+
+	t.Run("sql", func(t *testing.T) {
+		value, err := g.Value()
+		assert.NoError(t, err)
+		var actual geometry.EWKTGeometry
+		assert.NoError(t, actual.Scan(value))
+		assert.True(t, actual.Equals(g.Geom))
+		assert.Equal(t, 4326, actual.SRID())
+	})
+}
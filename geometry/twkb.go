@@ -0,0 +1,580 @@
+package geometry
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+
+	"github.com/twpayne/go-geos"
+)
+
+// TWKB metadata flags.
+const (
+	twkbFlagBBox              = 0x01
+	twkbFlagSize              = 0x02
+	twkbFlagIDList            = 0x04
+	twkbFlagExtendedPrecision = 0x08
+	twkbFlagEmpty             = 0x10
+)
+
+// TWKB extended precision flags.
+const (
+	twkbExtendedFlagZ = 0x01
+	twkbExtendedFlagM = 0x02
+)
+
+var (
+	twkbType = map[geos.TypeID]byte{
+		geos.TypeIDPoint:              1,
+		geos.TypeIDLineString:         2,
+		geos.TypeIDPolygon:            3,
+		geos.TypeIDMultiPoint:         4,
+		geos.TypeIDMultiLineString:    5,
+		geos.TypeIDMultiPolygon:       6,
+		geos.TypeIDGeometryCollection: 7,
+	}
+	twkbTypeID = map[byte]geos.TypeID{
+		1: geos.TypeIDPoint,
+		2: geos.TypeIDLineString,
+		3: geos.TypeIDPolygon,
+		4: geos.TypeIDMultiPoint,
+		5: geos.TypeIDMultiLineString,
+		6: geos.TypeIDMultiPolygon,
+		7: geos.TypeIDGeometryCollection,
+	}
+
+	errTWKBEmptyMultiPointMember = errors.New("TWKB empty MultiPoint member not supported")
+	errTWKBIDListLengthMismatch  = errors.New("TWKB id list length mismatch")
+	errTWKBInvalidLineString     = errors.New("TWKB invalid LineString")
+	errTWKBInvalidLinearRing     = errors.New("TWKB invalid LinearRing")
+	errTWKBPrecisionOutOfRange   = errors.New("TWKB precision out of range")
+	errTWKBUnsupportedIDList     = errors.New("TWKB id list not supported for type")
+	errTWKBUnsupportedM          = errors.New("TWKB M coordinates not supported")
+)
+
+// A TWKBEncoder writes geometries as TWKB (Tiny Well Known Binary) to an
+// io.Writer. Geometries with M coordinates and MultiPoints with empty members
+// are not supported.
+type TWKBEncoder struct {
+	w           io.Writer
+	precisionXY int
+	precisionZ  int
+	includeBBox bool
+	includeSize bool
+}
+
+// A TWKBEncoderOption sets an option on a TWKBEncoder.
+type TWKBEncoderOption func(*TWKBEncoder)
+
+// WithTWKBBBox sets whether to include bounding boxes.
+func WithTWKBBBox(includeBBox bool) TWKBEncoderOption {
+	return func(e *TWKBEncoder) {
+		e.includeBBox = includeBBox
+	}
+}
+
+// WithTWKBPrecisionXY sets the number of decimal places of X and Y
+// coordinates, which must be between -8 and 7. The default is 0.
+func WithTWKBPrecisionXY(precisionXY int) TWKBEncoderOption {
+	return func(e *TWKBEncoder) {
+		e.precisionXY = precisionXY
+	}
+}
+
+// WithTWKBPrecisionZ sets the number of decimal places of Z coordinates, which
+// must be between 0 and 7. The default is 0.
+func WithTWKBPrecisionZ(precisionZ int) TWKBEncoderOption {
+	return func(e *TWKBEncoder) {
+		e.precisionZ = precisionZ
+	}
+}
+
+// WithTWKBSize sets whether to include sizes.
+func WithTWKBSize(includeSize bool) TWKBEncoderOption {
+	return func(e *TWKBEncoder) {
+		e.includeSize = includeSize
+	}
+}
+
+// NewTWKBEncoder returns a new TWKBEncoder that writes to w.
+func NewTWKBEncoder(w io.Writer, options ...TWKBEncoderOption) *TWKBEncoder {
+	e := &TWKBEncoder{
+		w: w,
+	}
+	for _, option := range options {
+		option(e)
+	}
+	return e
+}
+
+// Encode writes g to the stream.
+func (e *TWKBEncoder) Encode(g *Geometry) error {
+	return e.EncodeWithIDs(g, nil)
+}
+
+// EncodeWithIDs writes g to the stream with ids as the id list. g must be a
+// multi-geometry or a geometry collection with the same number of geometries
+// as ids. If ids is nil then no id list is written.
+func (e *TWKBEncoder) EncodeWithIDs(g *Geometry, ids []int64) error {
+	if e.precisionXY < -8 || 7 < e.precisionXY {
+		return fmt.Errorf("%d: %w", e.precisionXY, errTWKBPrecisionOutOfRange)
+	}
+	if e.precisionZ < 0 || 7 < e.precisionZ {
+		return fmt.Errorf("%d: %w", e.precisionZ, errTWKBPrecisionOutOfRange)
+	}
+	data, _, err := e.encodeGeom(g.Geom, ids)
+	if err != nil {
+		return err
+	}
+	_, err = e.w.Write(data)
+	return err
+}
+
+// A twkbBounds is a bounding box in scaled integer coordinates.
+type twkbBounds struct {
+	min []int64
+	max []int64
+}
+
+func (b *twkbBounds) extend(coord []int64) {
+	if b.min == nil {
+		b.min = append([]int64(nil), coord...)
+		b.max = append([]int64(nil), coord...)
+		return
+	}
+	for i := range min(len(coord), len(b.min)) {
+		b.min[i] = min(b.min[i], coord[i])
+		b.max[i] = max(b.max[i], coord[i])
+	}
+}
+
+func (b *twkbBounds) union(other *twkbBounds) {
+	if other.min == nil {
+		return
+	}
+	b.extend(other.min)
+	b.extend(other.max)
+}
+
+// A twkbCoordWriter writes delta-encoded coordinates.
+type twkbCoordWriter struct {
+	scales []float64
+	prev   []int64
+	bounds twkbBounds
+}
+
+func (w *twkbCoordWriter) appendCoords(b []byte, coords [][]float64) []byte {
+	value := make([]int64, len(w.scales))
+	for _, coord := range coords {
+		for i, scale := range w.scales {
+			value[i] = int64(math.Round(coord[i] * scale))
+			b = binary.AppendVarint(b, value[i]-w.prev[i])
+			w.prev[i] = value[i]
+		}
+		w.bounds.extend(value)
+	}
+	return b
+}
+
+// appendPointArray appends the coordinates of geom, dropping points that are
+// identical to the previous point after rounding, as PostGIS does, while
+// keeping at least minPoints points.
+func (w *twkbCoordWriter) appendPointArray(b []byte, geom *geos.Geom, minPoints int) []byte {
+	coords := geom.CoordSeq().ToCoords()
+	value := make([]int64, len(w.scales))
+	var deltas []byte
+	numPoints, numPointsLeft := 0, len(coords)
+	for i, coord := range coords {
+		duplicate := true
+		for j, scale := range w.scales {
+			value[j] = int64(math.Round(coord[j] * scale))
+			duplicate = duplicate && value[j] == w.prev[j]
+		}
+		if i > 0 && duplicate && numPointsLeft > minPoints {
+			numPointsLeft--
+			continue
+		}
+		for j := range value {
+			deltas = binary.AppendVarint(deltas, value[j]-w.prev[j])
+			w.prev[j] = value[j]
+		}
+		w.bounds.extend(value)
+		numPoints++
+	}
+	b = binary.AppendUvarint(b, uint64(numPoints))
+	return append(b, deltas...)
+}
+
+func (w *twkbCoordWriter) appendPolygon(b []byte, geom *geos.Geom) []byte {
+	if geom.IsEmpty() {
+		return binary.AppendUvarint(b, 0)
+	}
+	numInteriorRings := geom.NumInteriorRings()
+	b = binary.AppendUvarint(b, uint64(1+numInteriorRings))
+	b = w.appendPointArray(b, geom.ExteriorRing(), 4)
+	for i := range numInteriorRings {
+		b = w.appendPointArray(b, geom.InteriorRing(i), 4)
+	}
+	return b
+}
+
+func (e *TWKBEncoder) encodeGeom(geom *geos.Geom, ids []int64) ([]byte, *twkbBounds, error) {
+	typeID := geom.TypeID()
+	typ, ok := twkbType[typeID]
+	if !ok {
+		return nil, nil, fmt.Errorf("unsupported type: %s", geom.Type())
+	}
+	hasZ := geom.HasZ()
+	if !geom.IsEmpty() {
+		dims := 2
+		if hasZ {
+			dims++
+		}
+		if geom.CoordinateDimension() > dims {
+			return nil, nil, errTWKBUnsupportedM
+		}
+	}
+	w := &twkbCoordWriter{
+		scales: []float64{math.Pow10(e.precisionXY), math.Pow10(e.precisionXY)},
+	}
+	if hasZ {
+		w.scales = append(w.scales, math.Pow10(e.precisionZ))
+	}
+	w.prev = make([]int64, len(w.scales))
+
+	var metadata byte
+	var body []byte
+	if geom.IsEmpty() {
+		metadata |= twkbFlagEmpty
+		if len(ids) != 0 {
+			return nil, nil, errTWKBIDListLengthMismatch
+		}
+	} else {
+		switch typeID {
+		case geos.TypeIDPoint, geos.TypeIDLineString, geos.TypeIDPolygon:
+			if ids != nil {
+				return nil, nil, fmt.Errorf("%s: %w", geom.Type(), errTWKBUnsupportedIDList)
+			}
+		default:
+			if ids != nil && len(ids) != geom.NumGeometries() {
+				return nil, nil, errTWKBIDListLengthMismatch
+			}
+			body = binary.AppendUvarint(body, uint64(geom.NumGeometries()))
+			if ids != nil {
+				metadata |= twkbFlagIDList
+				for _, id := range ids {
+					body = binary.AppendVarint(body, id)
+				}
+			}
+		}
+		switch typeID {
+		case geos.TypeIDPoint:
+			body = w.appendCoords(body, geom.CoordSeq().ToCoords())
+		case geos.TypeIDLineString:
+			body = w.appendPointArray(body, geom, 2)
+		case geos.TypeIDPolygon:
+			body = w.appendPolygon(body, geom)
+		case geos.TypeIDMultiPoint:
+			for i, n := 0, geom.NumGeometries(); i < n; i++ {
+				point := geom.Geometry(i)
+				if point.IsEmpty() {
+					return nil, nil, errTWKBEmptyMultiPointMember
+				}
+				body = w.appendCoords(body, point.CoordSeq().ToCoords())
+			}
+		case geos.TypeIDMultiLineString:
+			for i, n := 0, geom.NumGeometries(); i < n; i++ {
+				body = w.appendPointArray(body, geom.Geometry(i), 2)
+			}
+		case geos.TypeIDMultiPolygon:
+			for i, n := 0, geom.NumGeometries(); i < n; i++ {
+				body = w.appendPolygon(body, geom.Geometry(i))
+			}
+		case geos.TypeIDGeometryCollection:
+			for i, n := 0, geom.NumGeometries(); i < n; i++ {
+				data, bounds, err := e.encodeGeom(geom.Geometry(i), nil)
+				if err != nil {
+					return nil, nil, err
+				}
+				body = append(body, data...)
+				w.bounds.union(bounds)
+			}
+		}
+	}
+
+	var rest []byte
+	if e.includeBBox && w.bounds.min != nil {
+		metadata |= twkbFlagBBox
+		for i := range w.bounds.min {
+			rest = binary.AppendVarint(rest, w.bounds.min[i])
+			rest = binary.AppendVarint(rest, w.bounds.max[i]-w.bounds.min[i])
+		}
+	}
+	rest = append(rest, body...)
+
+	data := []byte{typ | byte(zigzag(e.precisionXY))<<4}
+	if hasZ {
+		metadata |= twkbFlagExtendedPrecision
+	}
+	if e.includeSize {
+		metadata |= twkbFlagSize
+	}
+	data = append(data, metadata)
+	if hasZ {
+		data = append(data, twkbExtendedFlagZ|byte(e.precisionZ)<<2)
+	}
+	if e.includeSize {
+		data = binary.AppendUvarint(data, uint64(len(rest)))
+	}
+	return append(data, rest...), &w.bounds, nil
+}
+
+// A TWKBDecoder reads geometries in TWKB (Tiny Well Known Binary) format from
+// an io.Reader.
+type TWKBDecoder struct {
+	r *bufio.Reader
+}
+
+// NewTWKBDecoder returns a new TWKBDecoder that reads from r.
+func NewTWKBDecoder(r io.Reader) *TWKBDecoder {
+	return &TWKBDecoder{
+		r: bufio.NewReader(r),
+	}
+}
+
+// NewGeometryFromTWKB returns a new Geometry from twkb.
+func NewGeometryFromTWKB(twkb []byte) (*Geometry, error) {
+	return NewTWKBDecoder(bytes.NewReader(twkb)).Decode()
+}
+
+// AsTWKB returns the TWKB representation of g.
+func (g *Geometry) AsTWKB(options ...TWKBEncoderOption) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := NewTWKBEncoder(&buf, options...).Encode(g); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Decode reads the next geometry from the stream. It returns io.EOF when there
+// are no more geometries.
+func (d *TWKBDecoder) Decode() (*Geometry, error) {
+	g, _, err := d.DecodeWithIDs()
+	return g, err
+}
+
+// DecodeWithIDs reads the next geometry and its id list, if any, from the
+// stream. It returns io.EOF when there are no more geometries.
+func (d *TWKBDecoder) DecodeWithIDs() (*Geometry, []int64, error) {
+	if _, err := d.r.Peek(1); err != nil {
+		return nil, nil, err
+	}
+	geom, ids, err := d.decodeGeom()
+	if errors.Is(err, io.EOF) {
+		err = io.ErrUnexpectedEOF
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return &Geometry{Geom: geom}, ids, nil
+}
+
+// A twkbCoordReader reads delta-encoded coordinates.
+type twkbCoordReader struct {
+	r      *bufio.Reader
+	scales []float64
+	prev   []int64
+}
+
+func (r *twkbCoordReader) readCoords(n uint64) ([][]float64, error) {
+	coords := make([][]float64, 0, min(n, 1024))
+	for range n {
+		coord := make([]float64, len(r.scales))
+		for i, scale := range r.scales {
+			delta, err := binary.ReadVarint(r.r)
+			if err != nil {
+				return nil, err
+			}
+			r.prev[i] += delta
+			coord[i] = float64(r.prev[i]) / scale
+		}
+		coords = append(coords, coord)
+	}
+	return coords, nil
+}
+
+func (r *twkbCoordReader) readPointArray() ([][]float64, error) {
+	n, err := binary.ReadUvarint(r.r)
+	if err != nil {
+		return nil, err
+	}
+	return r.readCoords(n)
+}
+
+func (r *twkbCoordReader) readLineString() (*geos.Geom, error) {
+	coords, err := r.readPointArray()
+	switch {
+	case err != nil:
+		return nil, err
+	case len(coords) == 0:
+		return geos.NewEmptyLineString(), nil
+	case !isLineStringCoords(coords):
+		return nil, errTWKBInvalidLineString
+	default:
+		return geos.NewLineString(coords), nil
+	}
+}
+
+// readPolygon reads a Polygon. Empty rings are skipped, but an empty exterior
+// ring must not be followed by non-empty interior rings.
+func (r *twkbCoordReader) readPolygon() (*geos.Geom, error) {
+	numRings, err := binary.ReadUvarint(r.r)
+	if err != nil {
+		return nil, err
+	}
+	coordss := make([][][]float64, 0, min(numRings, 1024))
+	for i := range numRings {
+		coords, err := r.readPointArray()
+		if err != nil {
+			return nil, err
+		}
+		if len(coords) == 0 {
+			continue
+		}
+		if !isLinearRingCoords(coords) || i > 0 && len(coordss) == 0 {
+			return nil, errTWKBInvalidLinearRing
+		}
+		coordss = append(coordss, coords)
+	}
+	if len(coordss) == 0 {
+		return geos.NewEmptyPolygon(), nil
+	}
+	return geos.NewPolygon(coordss), nil
+}
+
+func (d *TWKBDecoder) decodeGeom() (*geos.Geom, []int64, error) {
+	header, err := d.r.ReadByte()
+	if err != nil {
+		return nil, nil, err
+	}
+	typeID, ok := twkbTypeID[header&0x0f]
+	if !ok {
+		return nil, nil, fmt.Errorf("%d: unknown TWKB type", header&0x0f)
+	}
+	precisionXY := unzigzag(header >> 4)
+	metadata, err := d.r.ReadByte()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &twkbCoordReader{
+		r:      d.r,
+		scales: []float64{math.Pow10(precisionXY), math.Pow10(precisionXY)},
+	}
+	if metadata&twkbFlagExtendedPrecision != 0 {
+		extended, err := d.r.ReadByte()
+		if err != nil {
+			return nil, nil, err
+		}
+		if extended&twkbExtendedFlagM != 0 {
+			return nil, nil, errTWKBUnsupportedM
+		}
+		if extended&twkbExtendedFlagZ != 0 {
+			r.scales = append(r.scales, math.Pow10(int(extended>>2&0x07)))
+		}
+	}
+	r.prev = make([]int64, len(r.scales))
+	if metadata&twkbFlagSize != 0 {
+		if _, err := binary.ReadUvarint(d.r); err != nil {
+			return nil, nil, err
+		}
+	}
+	if metadata&twkbFlagEmpty != 0 {
+		switch typeID {
+		case geos.TypeIDPoint:
+			return geos.NewEmptyPoint(), nil, nil
+		case geos.TypeIDLineString:
+			return geos.NewEmptyLineString(), nil, nil
+		case geos.TypeIDPolygon:
+			return geos.NewEmptyPolygon(), nil, nil
+		default:
+			return geos.NewEmptyCollection(typeID), nil, nil
+		}
+	}
+	if metadata&twkbFlagBBox != 0 {
+		for range 2 * len(r.scales) {
+			if _, err := binary.ReadVarint(d.r); err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+
+	switch typeID {
+	case geos.TypeIDPoint:
+		coords, err := r.readCoords(1)
+		if err != nil {
+			return nil, nil, err
+		}
+		return geos.NewPoint(coords[0]), nil, nil
+	case geos.TypeIDLineString:
+		geom, err := r.readLineString()
+		return geom, nil, err
+	case geos.TypeIDPolygon:
+		geom, err := r.readPolygon()
+		return geom, nil, err
+	}
+
+	numGeoms, err := binary.ReadUvarint(d.r)
+	if err != nil {
+		return nil, nil, err
+	}
+	var ids []int64
+	if metadata&twkbFlagIDList != 0 {
+		ids = make([]int64, 0, min(numGeoms, 1024))
+		for range numGeoms {
+			id, err := binary.ReadVarint(d.r)
+			if err != nil {
+				return nil, nil, err
+			}
+			ids = append(ids, id)
+		}
+	}
+	geoms := make([]*geos.Geom, 0, min(numGeoms, 1024))
+	for range numGeoms {
+		var geom *geos.Geom
+		switch typeID {
+		case geos.TypeIDMultiPoint:
+			var coords [][]float64
+			coords, err = r.readCoords(1)
+			if err == nil {
+				geom = geos.NewPoint(coords[0])
+			}
+		case geos.TypeIDMultiLineString:
+			geom, err = r.readLineString()
+		case geos.TypeIDMultiPolygon:
+			geom, err = r.readPolygon()
+		case geos.TypeIDGeometryCollection:
+			geom, _, err = d.decodeGeom()
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		geoms = append(geoms, geom)
+	}
+	return geos.NewCollection(typeID, geoms), ids, nil
+}
+
+// zigzag returns the zigzag encoding of a small signed integer.
+func zigzag(i int) uint {
+	return uint(i<<1) ^ uint(i>>(strconv.IntSize-1))
+}
+
+// unzigzag returns the signed integer encoded in the low four bits of b.
+func unzigzag(b byte) int {
+	return int(b>>1) ^ -int(b&1)
+}
@@ -0,0 +1,258 @@
+package geometry_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"io"
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+
+	"github.com/twpayne/go-geos"
+	"github.com/twpayne/go-geos/geometry"
+)
+
+// Test cases with a postgis comment have twkbStrs returned by PostGIS's
+// ST_AsTWKB.
+func TestTWKB(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		wkt         string
+		options     []geometry.TWKBEncoderOption
+		ids         []int64
+		twkbStr     string
+		expectedWKT string
+	}{
+		{
+			name:    "point",
+			wkt:     "POINT (1 2)",
+			twkbStr: "01000204",
+		},
+		{
+			name:    "point_precision",
+			wkt:     "POINT (1.23 4.56)",
+			options: []geometry.TWKBEncoderOption{geometry.WithTWKBPrecisionXY(2)},
+			twkbStr: "4100f6019007",
+		},
+		{
+			name:        "point_negative_precision",
+			wkt:         "POINT (1234 5678)",
+			options:     []geometry.TWKBEncoderOption{geometry.WithTWKBPrecisionXY(-2)},
+			twkbStr:     "31001872",
+			expectedWKT: "POINT (1200 5700)",
+		},
+		{
+			name:    "point_z",
+			wkt:     "POINT Z (1 2 3)",
+			twkbStr: "010801020406",
+		},
+		{
+			name:    "point_empty",
+			wkt:     "POINT EMPTY",
+			twkbStr: "0110",
+		},
+		{
+			// postgis: ST_AsTWKB('LINESTRING(1 1,5 5)'::geometry)
+			name:    "linestring",
+			wkt:     "LINESTRING (1 1, 5 5)",
+			twkbStr: "02000202020808",
+		},
+		{
+			// postgis: ST_AsTWKB('LINESTRING(1 1,5 5)'::geometry, 0, 0, 0, true, true)
+			name: "linestring_bbox_size",
+			wkt:  "LINESTRING (1 1, 5 5)",
+			options: []geometry.TWKBEncoderOption{
+				geometry.WithTWKBBBox(true),
+				geometry.WithTWKBSize(true),
+			},
+			twkbStr: "020309020802080202020808",
+		},
+		{
+			name:        "linestring_duplicate",
+			wkt:         "LINESTRING (0 0, 0.1 0.1, 1 1)",
+			twkbStr:     "02000200000202",
+			expectedWKT: "LINESTRING (0 0, 1 1)",
+		},
+		{
+			name:        "linestring_duplicate_minimum",
+			wkt:         "LINESTRING (0 0, 0.1 0.1)",
+			twkbStr:     "02000200000000",
+			expectedWKT: "LINESTRING (0 0, 0 0)",
+		},
+		{
+			name:    "polygon",
+			wkt:     "POLYGON ((0 0, 1 0, 1 1, 0 1, 0 0))",
+			twkbStr: "0300010500000200000201000001",
+		},
+		{
+			name:        "polygon_duplicate",
+			wkt:         "POLYGON ((0 0, 1 0, 1.1 0, 1 1, 0 0))",
+			twkbStr:     "030001040000020000020101",
+			expectedWKT: "POLYGON ((0 0, 1 0, 1 1, 0 0))",
+		},
+		{
+			name:        "polygon_duplicate_minimum",
+			wkt:         "POLYGON ((0 0, 0.1 0, 0.1 0.1, 0 0))",
+			twkbStr:     "030001040000000000000000",
+			expectedWKT: "POLYGON ((0 0, 0 0, 0 0, 0 0))",
+		},
+		{
+			name:    "multipoint_ids",
+			wkt:     "MULTIPOINT ((1 1), (5 5))",
+			ids:     []int64{1, 2},
+			twkbStr: "040402020402020808",
+		},
+		{
+			// postgis: ST_AsTWKB(ARRAY['POINT(0 0)'::geometry, 'POINT(1 1)'::geometry], ARRAY[1, 2])
+			name:    "multipoint_ids_postgis",
+			wkt:     "MULTIPOINT ((0 0), (1 1))",
+			ids:     []int64{1, 2},
+			twkbStr: "040402020400000202",
+		},
+		{
+			name:    "multilinestring_empty_member",
+			wkt:     "MULTILINESTRING (EMPTY, (0 0, 1 1))",
+			twkbStr: "050002000200000202",
+		},
+		{
+			name:    "multipolygon_empty_member",
+			wkt:     "MULTIPOLYGON (EMPTY, ((0 0, 1 0, 1 1, 0 0)))",
+			twkbStr: "0600020001040000020000020101",
+		},
+		{
+			name:    "geometrycollection",
+			wkt:     "GEOMETRYCOLLECTION (POINT (1 2), LINESTRING (1 1, 5 5))",
+			twkbStr: "0700020100020402000202020808",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			g := mustNewGeometryFromWKT(t, tc.wkt)
+			var buf bytes.Buffer
+			assert.NoError(t, geometry.NewTWKBEncoder(&buf, tc.options...).EncodeWithIDs(g, tc.ids))
+			assert.Equal(t, tc.twkbStr, hex.EncodeToString(buf.Bytes()))
+
+			expectedWKT := tc.expectedWKT
+			if expectedWKT == "" {
+				expectedWKT = tc.wkt
+			}
+			expected := mustNewGeometryFromWKT(t, expectedWKT)
+			actual, ids, err := geometry.NewTWKBDecoder(&buf).DecodeWithIDs()
+			assert.NoError(t, err)
+			assert.True(t, expected.EqualsIdentical(actual.Geom))
+			assert.Equal(t, tc.ids, ids)
+		})
+	}
+}
+
+func TestTWKBDecode(t *testing.T) {
+	for _, tc := range []struct {
+		name          string
+		twkbStr       string
+		expectedWKT   string
+		expectedError bool
+	}{
+		{
+			name:        "linestring_no_points",
+			twkbStr:     "020000",
+			expectedWKT: "LINESTRING EMPTY",
+		},
+		{
+			name:          "linestring_one_point",
+			twkbStr:       "0200010202",
+			expectedError: true,
+		},
+		{
+			name:          "multilinestring_one_point",
+			twkbStr:       "050001010202",
+			expectedError: true,
+		},
+		{
+			name:        "polygon_empty_ring",
+			twkbStr:     "03000100",
+			expectedWKT: "POLYGON EMPTY",
+		},
+		{
+			name:        "polygon_empty_interior_ring",
+			twkbStr:     "03000204000002000002010100",
+			expectedWKT: "POLYGON ((0 0, 1 0, 1 1, 0 0))",
+		},
+		{
+			name:          "polygon_empty_exterior_ring",
+			twkbStr:       "03000200040000020000020101",
+			expectedError: true,
+		},
+		{
+			name:          "polygon_short_ring",
+			twkbStr:       "03000103000002000100",
+			expectedError: true,
+		},
+		{
+			name:          "polygon_unclosed_ring",
+			twkbStr:       "030001040000020000020100",
+			expectedError: true,
+		},
+		{
+			name:          "multipolygon_unclosed_ring",
+			twkbStr:       "06000101040000020000020100",
+			expectedError: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			data, err := hex.DecodeString(tc.twkbStr)
+			assert.NoError(t, err)
+			actual, err := geometry.NewGeometryFromTWKB(data)
+			if tc.expectedError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.True(t, mustNewGeometryFromWKT(t, tc.expectedWKT).EqualsIdentical(actual.Geom))
+		})
+	}
+}
+
+func TestTWKBStream(t *testing.T) {
+	var buf bytes.Buffer
+	encoder := geometry.NewTWKBEncoder(&buf, geometry.WithTWKBPrecisionXY(1))
+	wkts := []string{
+		"POINT (0.5 1.5)",
+		"MULTILINESTRING ((0 0, 1 1), (2 2, 3 3))",
+		"MULTIPOLYGON (((0 0, 1 0, 1 1, 0 0)), ((2 2, 3 2, 3 3, 2 2)))",
+	}
+	for _, wkt := range wkts {
+		assert.NoError(t, encoder.Encode(mustNewGeometryFromWKT(t, wkt)))
+	}
+
+	decoder := geometry.NewTWKBDecoder(&buf)
+	for _, wkt := range wkts {
+		actual, err := decoder.Decode()
+		assert.NoError(t, err)
+		assert.True(t, mustNewGeometryFromWKT(t, wkt).EqualsIdentical(actual.Geom))
+	}
+	_, err := decoder.Decode()
+	assert.Equal(t, io.EOF, err)
+}
+
+func TestTWKBErrors(t *testing.T) {
+	g := mustNewGeometryFromWKT(t, "POINT (1 2)")
+	_, err := g.AsTWKB(geometry.WithTWKBPrecisionXY(8))
+	assert.Error(t, err)
+	assert.Error(t, geometry.NewTWKBEncoder(io.Discard).EncodeWithIDs(g, []int64{1}))
+
+	multiPoint := geometry.NewGeometry(geos.NewCollection(geos.TypeIDMultiPoint, []*geos.Geom{
+		geos.NewEmptyPoint(),
+		geos.NewPoint([]float64{1, 2}),
+	}))
+	_, err = multiPoint.AsTWKB()
+	assert.Error(t, err)
+
+	if geos.VersionCompare(3, 12, 0) >= 0 {
+		_, err = mustNewGeometryFromWKT(t, "POINT M (1 2 3)").AsTWKB()
+		assert.Error(t, err)
+		_, err = mustNewGeometryFromWKT(t, "LINESTRING ZM (1 2 3 4, 5 6 7 8)").AsTWKB()
+		assert.Error(t, err)
+	}
+
+	_, err = geometry.NewGeometryFromTWKB([]byte{0x02, 0x00, 0x02, 0x02})
+	assert.Equal(t, io.ErrUnexpectedEOF, err)
+}
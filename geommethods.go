@@ -365,6 +365,23 @@ func (g *Geom) EqualsExact(other *Geom, tolerance float64) bool {
 	}
 }
 
+// #cgo nocallback GEOSEqualsIdentical_r
+// #cgo noescape GEOSEqualsIdentical_r
+
+// EqualsIdentical returns true if g and other are structurally identical, including their types, structures, ordering of coordinates, and coordinate dimensions.
+func (g *Geom) EqualsIdentical(other *Geom) bool {
+	g.context.mutex.Lock()
+	defer g.context.mutex.Unlock()
+	switch C.GEOSEqualsIdentical_r(g.context.cHandle, g.cGeom, other.cGeom) {
+	case 0:
+		return false
+	case 1:
+		return true
+	default:
+		panic(g.context.err)
+	}
+}
+
 // #cgo nocallback GEOSFrechetDistance_r
 // #cgo noescape GEOSFrechetDistance_r
 
@@ -614,6 +631,16 @@ func (g *Geom) LineMerge() *Geom {
 	return g.context.newNonNilGeom(C.GEOSLineMerge_r(g.context.cHandle, g.cGeom), nil)
 }
 
+// #cgo nocallback GEOSLineMergeDirected_r
+// #cgo noescape GEOSLineMergeDirected_r
+
+// LineMergeDirected returns a set of fully noded LineStrings, removing any cardinality 2 nodes in the linework, only joining lines with consistent directions.
+func (g *Geom) LineMergeDirected() *Geom {
+	g.context.mutex.Lock()
+	defer g.context.mutex.Unlock()
+	return g.context.newNonNilGeom(C.GEOSLineMergeDirected_r(g.context.cHandle, g.cGeom), nil)
+}
+
 // #cgo nocallback GEOSMakeValid_r
 // #cgo noescape GEOSMakeValid_r
 
@@ -769,6 +796,16 @@ func (g *Geom) RelateBoundaryNodeRule(other *Geom, bnr RelateBoundaryNodeRule) s
 	return C.GoString(relateBoundaryNodeRuleCStr)
 }
 
+// #cgo nocallback GEOSRemoveRepeatedPoints_r
+// #cgo noescape GEOSRemoveRepeatedPoints_r
+
+// RemoveRepeatedPoints returns g with repeated points within tolerance removed.
+func (g *Geom) RemoveRepeatedPoints(tolerance float64) *Geom {
+	g.context.mutex.Lock()
+	defer g.context.mutex.Unlock()
+	return g.context.newNonNilGeom(C.GEOSRemoveRepeatedPoints_r(g.context.cHandle, g.cGeom, C.double(tolerance)), nil)
+}
+
 // #cgo nocallback GEOSReverse_r
 // #cgo noescape GEOSReverse_r
 
@@ -916,6 +953,16 @@ func (g *Geom) UnionPrec(other *Geom, gridSize float64) *Geom {
 	return g.context.newGeom(C.GEOSUnionPrec_r(g.context.cHandle, g.cGeom, other.cGeom, C.double(gridSize)), nil)
 }
 
+// #cgo nocallback GEOSGeom_extractUniquePoints_r
+// #cgo noescape GEOSGeom_extractUniquePoints_r
+
+// UniquePoints returns a MultiPoint of the unique points of g.
+func (g *Geom) UniquePoints() *Geom {
+	g.context.mutex.Lock()
+	defer g.context.mutex.Unlock()
+	return g.context.newNonNilGeom(C.GEOSGeom_extractUniquePoints_r(g.context.cHandle, g.cGeom), nil)
+}
+
 // #cgo nocallback GEOSWithin_r
 // #cgo noescape GEOSWithin_r
 
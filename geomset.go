@@ -0,0 +1,138 @@
+package geos
+
+import (
+	"encoding/binary"
+	"hash/fnv"
+	"math"
+)
+
+// A GeomSetMode determines when two geometries are considered equal by a
+// GeomSet.
+type GeomSetMode int
+
+// GeomSet modes.
+const (
+	// GeomSetModeExact considers geometries equal if they are identical after
+	// normalization, see Normalize and EqualsIdentical.
+	GeomSetModeExact GeomSetMode = iota
+	// GeomSetModeTopological considers geometries equal if they are
+	// topologically equal, see Equals.
+	GeomSetModeTopological
+)
+
+// A GeomSet is a set of geometries.
+type GeomSet struct {
+	mode    GeomSetMode
+	buckets map[uint64][]geomSetEntry
+	len     int
+}
+
+type geomSetEntry struct {
+	geom *Geom
+	key  *Geom
+}
+
+// NewGeomSet returns a new empty GeomSet using mode.
+func NewGeomSet(mode GeomSetMode) *GeomSet {
+	return &GeomSet{
+		mode:    mode,
+		buckets: make(map[uint64][]geomSetEntry),
+	}
+}
+
+// Add adds g to s. It returns true if g was added, or false if s already
+// contains a geometry equal to g.
+func (s *GeomSet) Add(g *Geom) bool {
+	hash, key := s.hashAndKey(g)
+	if s.index(hash, key) != -1 {
+		return false
+	}
+	s.buckets[hash] = append(s.buckets[hash], geomSetEntry{
+		geom: g,
+		key:  key,
+	})
+	s.len++
+	return true
+}
+
+// Contains returns true if s contains a geometry equal to g.
+func (s *GeomSet) Contains(g *Geom) bool {
+	hash, key := s.hashAndKey(g)
+	return s.index(hash, key) != -1
+}
+
+// Geoms returns the geometries in s, in no particular order.
+func (s *GeomSet) Geoms() []*Geom {
+	geoms := make([]*Geom, 0, s.len)
+	for _, entries := range s.buckets {
+		for _, entry := range entries {
+			geoms = append(geoms, entry.geom)
+		}
+	}
+	return geoms
+}
+
+// Len returns the number of geometries in s.
+func (s *GeomSet) Len() int {
+	return s.len
+}
+
+// Remove removes the geometry equal to g from s. It returns true if a
+// geometry was removed.
+func (s *GeomSet) Remove(g *Geom) bool {
+	hash, key := s.hashAndKey(g)
+	i := s.index(hash, key)
+	if i == -1 {
+		return false
+	}
+	entries := s.buckets[hash]
+	if len(entries) == 1 {
+		delete(s.buckets, hash)
+	} else {
+		s.buckets[hash] = append(entries[:i], entries[i+1:]...)
+	}
+	s.len--
+	return true
+}
+
+// hashAndKey returns the hash of g and the geometry used to compare g with
+// other geometries in s.
+func (s *GeomSet) hashAndKey(g *Geom) (uint64, *Geom) {
+	switch s.mode {
+	case GeomSetModeTopological:
+		// Topologically equal geometries have equal bounds, but not
+		// necessarily equal normalized forms.
+		bounds := g.Bounds()
+		h := fnv.New64a()
+		var data [32]byte
+		binary.LittleEndian.PutUint64(data[0:8], math.Float64bits(bounds.MinX))
+		binary.LittleEndian.PutUint64(data[8:16], math.Float64bits(bounds.MinY))
+		binary.LittleEndian.PutUint64(data[16:24], math.Float64bits(bounds.MaxX))
+		binary.LittleEndian.PutUint64(data[24:32], math.Float64bits(bounds.MaxY))
+		h.Write(data[:])
+		return h.Sum64(), g
+	default:
+		key := g.normalizedClone(0)
+		h := fnv.New64a()
+		h.Write(key.ToWKB())
+		return h.Sum64(), key
+	}
+}
+
+// index returns the index of the entry equal to key in the bucket for hash,
+// or -1 if there is no such entry.
+func (s *GeomSet) index(hash uint64, key *Geom) int {
+	for i, entry := range s.buckets[hash] {
+		switch s.mode {
+		case GeomSetModeTopological:
+			if entry.key.Equals(key) {
+				return i
+			}
+		default:
+			if entry.key.EqualsIdentical(key) {
+				return i
+			}
+		}
+	}
+	return -1
+}
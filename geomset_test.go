@@ -0,0 +1,43 @@
+package geos_test
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+
+	"github.com/twpayne/go-geos"
+)
+
+func TestGeomSetExact(t *testing.T) {
+	defer runtime.GC() // Exercise finalizers.
+	c := geos.NewContext()
+	s := geos.NewGeomSet(geos.GeomSetModeExact)
+	assert.True(t, s.Add(mustNewGeomFromWKT(t, c, "POLYGON ((0 0, 1 0, 1 1, 0 1, 0 0))")))
+	assert.False(t, s.Add(mustNewGeomFromWKT(t, c, "POLYGON ((0 0, 1 0, 1 1, 0 1, 0 0))")))
+	assert.False(t, s.Add(mustNewGeomFromWKT(t, c, "POLYGON ((0 0, 0 1, 1 1, 1 0, 0 0))")))
+	assert.True(t, s.Add(mustNewGeomFromWKT(t, c, "POLYGON ((0 0, 0.5 0, 1 0, 1 1, 0 1, 0 0))")))
+	assert.True(t, s.Add(mustNewGeomFromWKT(t, c, "POINT (0 0)")))
+	assert.Equal(t, 3, s.Len())
+	assert.Equal(t, 3, len(s.Geoms()))
+
+	assert.True(t, s.Contains(mustNewGeomFromWKT(t, c, "POINT (0 0)")))
+	assert.False(t, s.Contains(mustNewGeomFromWKT(t, c, "MULTIPOINT ((0 0))")))
+	assert.True(t, s.Remove(mustNewGeomFromWKT(t, c, "POINT (0 0)")))
+	assert.False(t, s.Remove(mustNewGeomFromWKT(t, c, "POINT (0 0)")))
+	assert.False(t, s.Contains(mustNewGeomFromWKT(t, c, "POINT (0 0)")))
+	assert.Equal(t, 2, s.Len())
+}
+
+func TestGeomSetTopological(t *testing.T) {
+	defer runtime.GC() // Exercise finalizers.
+	c := geos.NewContext()
+	s := geos.NewGeomSet(geos.GeomSetModeTopological)
+	assert.True(t, s.Add(mustNewGeomFromWKT(t, c, "POLYGON ((0 0, 1 0, 1 1, 0 1, 0 0))")))
+	assert.False(t, s.Add(mustNewGeomFromWKT(t, c, "POLYGON ((0 0, 0 1, 1 1, 1 0, 0 0))")))
+	assert.False(t, s.Add(mustNewGeomFromWKT(t, c, "POLYGON ((0 0, 0.5 0, 1 0, 1 1, 0 1, 0 0))")))
+	assert.True(t, s.Add(mustNewGeomFromWKT(t, c, "POLYGON ((0 0, 2 0, 2 2, 0 2, 0 0))")))
+	assert.Equal(t, 2, s.Len())
+	assert.True(t, s.Remove(mustNewGeomFromWKT(t, c, "POLYGON ((1 0, 1 1, 0 1, 0 0, 1 0))")))
+	assert.Equal(t, 1, s.Len())
+}
@@ -62,6 +62,18 @@ const (
 	BufJoinStyleBevel BufJoinStyle = C.GEOSBUF_JOIN_BEVEL
 )
 
+// A BufSide is the side of a single-sided buffer.
+type BufSide int
+
+// Buffer sides.
+const (
+	BufSideLeft  BufSide = 1
+	BufSideRight BufSide = -1
+)
+
+// ewktSRIDPrefix is the prefix of the SRID in Extended WKT.
+const ewktSRIDPrefix = "SRID="
+
 // An Error is an error returned by GEOS.
 type Error string
 
@@ -70,10 +82,24 @@ func (e Error) Error() string {
 }
 
 var (
-	errContextMismatch     = Error("context mismatch")
-	errDimensionOutOfRange = Error("dimension out of range")
-	errDuplicateValue      = Error("duplicate value")
-	errIndexOutOfRange     = Error("index out of range")
+	errCannotSequence                   = Error("cannot sequence")
+	errContextMismatch                  = Error("context mismatch")
+	errDimensionOutOfRange              = Error("dimension out of range")
+	errDuplicateValue                   = Error("duplicate value")
+	errGridSizeOutOfRange               = Error("grid size out of range")
+	errIndexOutOfRange                  = Error("index out of range")
+	errInvalidBufCapStyle               = Error("invalid buffer cap style")
+	errInvalidBufJoinStyle              = Error("invalid buffer join style")
+	errInvalidEWKT                      = Error("invalid EWKT")
+	errInvalidIntersectionMatrix        = Error("invalid intersection matrix")
+	errInvalidIntersectionMatrixPattern = Error("invalid intersection matrix pattern")
+	errInvalidWKBByteOrder              = Error("invalid WKB byte order")
+	errInvalidWKBFraming                = Error("invalid WKB framing")
+	errLengthMismatch                   = Error("length mismatch")
+	errLevelOutOfRange                  = Error("level out of range")
+	errMaxVerticesOutOfRange            = Error("max vertices out of range")
+	errUnsupportedGEOSVersion           = Error("unsupported GEOS version")
+	errUnsupportedTypeID                = Error("unsupported type id")
 )
 
 type PrecisionRule int
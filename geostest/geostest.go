@@ -0,0 +1,169 @@
+// Package geostest provides helpers for testing code that uses GEOS geometries.
+package geostest
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/twpayne/go-geos"
+)
+
+// AssertEqualsExact fails tb if expected and actual are not equal after
+// normalization with all coordinates within tolerance of each other.
+func AssertEqualsExact(tb testing.TB, expected, actual *geos.Geom, tolerance float64) {
+	tb.Helper()
+	if diff := Diff(expected, actual, tolerance); diff != "" {
+		tb.Fatalf("geometries are not equal:\nexpected: %s\nactual:   %s\n%s", toWKT(expected), toWKT(actual), diff)
+	}
+}
+
+// AssertEqualsTopo fails tb if expected and actual are not topologically
+// equal.
+func AssertEqualsTopo(tb testing.TB, expected, actual *geos.Geom) {
+	tb.Helper()
+	switch {
+	case expected == nil && actual == nil:
+		return
+	case expected == nil || actual == nil:
+	case expected.IsEmpty() && actual.IsEmpty():
+		return
+	case expected.IsEmpty() != actual.IsEmpty():
+	case expected.Equals(actual):
+		return
+	}
+	tb.Fatalf("geometries are not topologically equal:\nexpected: %s\nactual:   %s\n%s", toWKT(expected), toWKT(actual), Diff(expected, actual, 0))
+}
+
+// AssertValid fails tb if g is not valid.
+func AssertValid(tb testing.TB, g *geos.Geom) {
+	tb.Helper()
+	if g == nil {
+		tb.Fatalf("geometry is nil")
+		return
+	}
+	validDetail := g.IsValidDetail(geos.ValidFlagNone)
+	if validDetail.Valid {
+		return
+	}
+	if validDetail.Location != nil {
+		tb.Fatalf("geometry is not valid: %s at %s\n%s", validDetail.Message, validDetail.Location.ToWKT(), g.ToWKT())
+	} else {
+		tb.Fatalf("geometry is not valid: %s\n%s", validDetail.Message, g.ToWKT())
+	}
+}
+
+// Diff returns a human-readable description of the differences between
+// expected and actual after normalization, one difference per line, or the
+// empty string if there are no differences. Coordinates are considered equal
+// if they are within tolerance of each other.
+func Diff(expected, actual *geos.Geom, tolerance float64) string {
+	switch {
+	case expected == nil && actual == nil:
+		return ""
+	case expected == nil:
+		return "expected nil, got " + actual.ToWKT()
+	case actual == nil:
+		return "expected " + expected.ToWKT() + ", got nil"
+	}
+	d := &differ{
+		tolerance: tolerance,
+	}
+	d.diff(nil, expected.Clone().Normalize(), actual.Clone().Normalize())
+	return strings.Join(d.lines, "\n")
+}
+
+type differ struct {
+	tolerance float64
+	lines     []string
+}
+
+func (d *differ) addf(path []string, format string, args ...any) {
+	message := fmt.Sprintf(format, args...)
+	if len(path) > 0 {
+		message = strings.Join(path, ", ") + ": " + message
+	}
+	d.lines = append(d.lines, message)
+}
+
+func (d *differ) diff(path []string, expected, actual *geos.Geom) {
+	if expected.TypeID() != actual.TypeID() {
+		d.addf(path, "expected %s, got %s", expected.Type(), actual.Type())
+		return
+	}
+	if expectedEmpty, actualEmpty := expected.IsEmpty(), actual.IsEmpty(); expectedEmpty || actualEmpty {
+		switch {
+		case expectedEmpty && !actualEmpty:
+			d.addf(path, "expected empty, got %s", actual.ToWKT())
+		case !expectedEmpty && actualEmpty:
+			d.addf(path, "expected %s, got empty", expected.ToWKT())
+		}
+		return
+	}
+	switch expected.TypeID() {
+	case geos.TypeIDPoint, geos.TypeIDLineString, geos.TypeIDLinearRing:
+		d.diffCoords(path, expected.CoordSeq().ToCoords(), actual.CoordSeq().ToCoords())
+	case geos.TypeIDPolygon:
+		d.diff(append(path, "exterior ring"), expected.ExteriorRing(), actual.ExteriorRing())
+		expectedNumInteriorRings, actualNumInteriorRings := expected.NumInteriorRings(), actual.NumInteriorRings()
+		if expectedNumInteriorRings != actualNumInteriorRings {
+			d.addf(path, "expected %d interior rings, got %d", expectedNumInteriorRings, actualNumInteriorRings)
+		}
+		for i := range min(expectedNumInteriorRings, actualNumInteriorRings) {
+			d.diff(append(path, "interior ring "+strconv.Itoa(i)), expected.InteriorRing(i), actual.InteriorRing(i))
+		}
+	default:
+		expectedNumGeometries, actualNumGeometries := expected.NumGeometries(), actual.NumGeometries()
+		if expectedNumGeometries != actualNumGeometries {
+			d.addf(path, "expected %d geometries, got %d", expectedNumGeometries, actualNumGeometries)
+		}
+		for i := range min(expectedNumGeometries, actualNumGeometries) {
+			d.diff(append(path, "geometry "+strconv.Itoa(i)), expected.Geometry(i), actual.Geometry(i))
+		}
+	}
+}
+
+func (d *differ) diffCoords(path []string, expected, actual [][]float64) {
+	if len(expected) != len(actual) {
+		d.addf(path, "expected %d vertices, got %d", len(expected), len(actual))
+	}
+	for i := range min(len(expected), len(actual)) {
+		if !d.coordsEqual(expected[i], actual[i]) {
+			d.addf(append(path, "vertex "+strconv.Itoa(i)), "expected %s, got %s", formatCoord(expected[i]), formatCoord(actual[i]))
+		}
+	}
+}
+
+func (d *differ) coordsEqual(expected, actual []float64) bool {
+	if len(expected) != len(actual) {
+		return false
+	}
+	if math.Hypot(expected[0]-actual[0], expected[1]-actual[1]) > d.tolerance {
+		return false
+	}
+	for i := 2; i < len(expected); i++ {
+		switch {
+		case math.IsNaN(expected[i]) && math.IsNaN(actual[i]):
+		case math.Abs(expected[i]-actual[i]) > d.tolerance:
+			return false
+		}
+	}
+	return true
+}
+
+func formatCoord(coord []float64) string {
+	ss := make([]string, len(coord))
+	for i, ordinate := range coord {
+		ss[i] = strconv.FormatFloat(ordinate, 'f', -1, 64)
+	}
+	return "(" + strings.Join(ss, " ") + ")"
+}
+
+func toWKT(g *geos.Geom) string {
+	if g == nil {
+		return "<nil>"
+	}
+	return g.ToWKT()
+}
@@ -0,0 +1,144 @@
+package geostest_test
+
+import (
+	"fmt"
+	"runtime"
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+
+	"github.com/twpayne/go-geos"
+	"github.com/twpayne/go-geos/geostest"
+)
+
+type recordingTB struct {
+	testing.TB
+	messages []string
+}
+
+func (tb *recordingTB) Fatalf(format string, args ...any) {
+	tb.messages = append(tb.messages, fmt.Sprintf(format, args...))
+}
+
+func (tb *recordingTB) Helper() {}
+
+func TestAssertEqualsExact(t *testing.T) {
+	defer runtime.GC() // Exercise finalizers.
+	c := geos.NewContext()
+	expected := mustNewGeomFromWKT(t, c, "POLYGON ((0 0, 1 0, 1 1, 0 1, 0 0))")
+
+	geostest.AssertEqualsExact(t, expected, mustNewGeomFromWKT(t, c, "POLYGON ((0 0, 0 1, 1 1, 1 0, 0 0))"), 0)
+	geostest.AssertEqualsExact(t, expected, mustNewGeomFromWKT(t, c, "POLYGON ((0 0, 1 0, 1 1.0001, 0 1, 0 0))"), 1e-3)
+
+	tb := &recordingTB{TB: t}
+	geostest.AssertEqualsExact(tb, expected, mustNewGeomFromWKT(t, c, "POLYGON ((0 0, 1 0, 1 1.1, 0 1, 0 0))"), 1e-3)
+	assert.Equal(t, 1, len(tb.messages))
+}
+
+func TestAssertEqualsTopo(t *testing.T) {
+	defer runtime.GC() // Exercise finalizers.
+	c := geos.NewContext()
+	expected := mustNewGeomFromWKT(t, c, "POLYGON ((0 0, 1 0, 1 1, 0 1, 0 0))")
+
+	geostest.AssertEqualsTopo(t, expected, mustNewGeomFromWKT(t, c, "POLYGON ((0 0, 0.5 0, 1 0, 1 1, 0 1, 0 0))"))
+	geostest.AssertEqualsTopo(t, mustNewGeomFromWKT(t, c, "POINT EMPTY"), mustNewGeomFromWKT(t, c, "POLYGON EMPTY"))
+
+	tb := &recordingTB{TB: t}
+	geostest.AssertEqualsTopo(tb, expected, mustNewGeomFromWKT(t, c, "POLYGON ((0 0, 2 0, 2 2, 0 2, 0 0))"))
+	geostest.AssertEqualsTopo(tb, expected, nil)
+	geostest.AssertEqualsTopo(tb, expected, mustNewGeomFromWKT(t, c, "POLYGON EMPTY"))
+	assert.Equal(t, 3, len(tb.messages))
+}
+
+func TestAssertValid(t *testing.T) {
+	defer runtime.GC() // Exercise finalizers.
+	c := geos.NewContext()
+
+	geostest.AssertValid(t, mustNewGeomFromWKT(t, c, "POLYGON ((0 0, 1 0, 1 1, 0 1, 0 0))"))
+
+	tb := &recordingTB{TB: t}
+	invalid, err := c.NewGeomFromWKT("POLYGON ((0 0, 1 1, 1 0, 0 1, 0 0))")
+	assert.NoError(t, err)
+	geostest.AssertValid(tb, invalid)
+	assert.Equal(t, []string{
+		"geometry is not valid: Self-intersection at POINT (0.5 0.5)\nPOLYGON ((0 0, 1 1, 1 0, 0 1, 0 0))",
+	}, tb.messages)
+}
+
+func TestDiff(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		expectedWKT string
+		actualWKT   string
+		tolerance   float64
+		expected    string
+	}{
+		{
+			name:        "equal",
+			expectedWKT: "LINESTRING (0 0, 1 1)",
+			actualWKT:   "LINESTRING (0 0, 1 1)",
+		},
+		{
+			name:        "normalized",
+			expectedWKT: "MULTIPOINT ((0 0), (1 1))",
+			actualWKT:   "MULTIPOINT ((1 1), (0 0))",
+		},
+		{
+			name:        "within_tolerance",
+			expectedWKT: "POINT (0 0)",
+			actualWKT:   "POINT (0.01 0)",
+			tolerance:   0.1,
+		},
+		{
+			name:        "type",
+			expectedWKT: "POINT (0 0)",
+			actualWKT:   "MULTIPOINT ((0 0))",
+			expected:    "expected Point, got MultiPoint",
+		},
+		{
+			name:        "vertex",
+			expectedWKT: "LINESTRING (0 0, 1 1, 2 2)",
+			actualWKT:   "LINESTRING (0 0, 1 1.5, 2 2)",
+			expected:    "vertex 1: expected (1 1), got (1 1.5)",
+		},
+		{
+			name:        "num_vertices",
+			expectedWKT: "LINESTRING (0 0, 1 1)",
+			actualWKT:   "LINESTRING (0 0, 1 1, 2 2)",
+			expected:    "expected 2 vertices, got 3",
+		},
+		{
+			name:        "interior_ring",
+			expectedWKT: "POLYGON ((0 0, 10 0, 10 10, 0 10, 0 0), (1 1, 2 1, 2 2, 1 2, 1 1))",
+			actualWKT:   "POLYGON ((0 0, 10 0, 10 10, 0 10, 0 0), (1 1, 3 1, 2 2, 1 2, 1 1))",
+			expected:    "interior ring 0, vertex 1: expected (2 1), got (3 1)",
+		},
+		{
+			name:        "num_geometries",
+			expectedWKT: "MULTIPOINT ((0 0), (1 1))",
+			actualWKT:   "MULTIPOINT ((0 0))",
+			expected:    "expected 2 geometries, got 1",
+		},
+		{
+			name:        "empty",
+			expectedWKT: "POINT EMPTY",
+			actualWKT:   "POINT (0 0)",
+			expected:    "expected empty, got POINT (0 0)",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			defer runtime.GC() // Exercise finalizers.
+			c := geos.NewContext()
+			expected := mustNewGeomFromWKT(t, c, tc.expectedWKT)
+			actual := mustNewGeomFromWKT(t, c, tc.actualWKT)
+			assert.Equal(t, tc.expected, geostest.Diff(expected, actual, tc.tolerance))
+		})
+	}
+}
+
+func mustNewGeomFromWKT(tb testing.TB, c *geos.Context, wkt string) *geos.Geom {
+	tb.Helper()
+	g, err := c.NewGeomFromWKT(wkt)
+	assert.NoError(tb, err)
+	return g
+}
@@ -1,5 +1,7 @@
 #include "go-geos.h"
 
+#include <stdlib.h>
+
 // Using cgo to call C functions from Go has a high overhead. The functions in
 // this file batch multiple calls to GEOS in C (rather than Go) to increase
 // performance.
@@ -17,6 +19,116 @@ void c_GEOSGeom_setUserData_r(GEOSContextHandle_t handle, GEOSGeometry *g,
   GEOSGeom_setUserData_r(handle, g, (void *)userdata);
 }
 
+// c_GEOSCluster_r clusters the ngeoms geometries geoms using method and writes
+// the cluster id of each geometry, or -1 if the geometry is not in any cluster,
+// to clusterIDs. It returns the number of clusters, or -1 on any exception.
+// Clustering was added in GEOS 3.14, so with earlier versions it always
+// returns -1.
+int c_GEOSCluster_r(GEOSContextHandle_t handle,
+                    const GEOSGeometry *const *geoms, unsigned int ngeoms,
+                    enum c_GEOSClusterMethod method, double d,
+                    unsigned int minPoints, int *clusterIDs) {
+#if GEOS_VERSION_MAJOR > 3 ||                                                  \
+    (GEOS_VERSION_MAJOR == 3 && GEOS_VERSION_MINOR >= 14)
+  GEOSGeometry **clones = malloc(ngeoms * sizeof(GEOSGeometry *));
+  if (clones == NULL) {
+    return -1;
+  }
+  for (unsigned int i = 0; i < ngeoms; ++i) {
+    clones[i] = GEOSGeom_clone_r(handle, geoms[i]);
+    if (clones[i] == NULL) {
+      for (unsigned int j = 0; j < i; ++j) {
+        GEOSGeom_destroy_r(handle, clones[j]);
+      }
+      free(clones);
+      return -1;
+    }
+  }
+  GEOSGeometry *collection = GEOSGeom_createCollection_r(
+      handle, GEOS_GEOMETRYCOLLECTION, clones, ngeoms);
+  if (collection == NULL) {
+    for (unsigned int i = 0; i < ngeoms; ++i) {
+      GEOSGeom_destroy_r(handle, clones[i]);
+    }
+    free(clones);
+    return -1;
+  }
+  free(clones);
+
+  GEOSClusterInfo *clusterInfo = NULL;
+  switch (method) {
+  case c_GEOS_CLUSTER_DBSCAN:
+    clusterInfo = GEOSClusterDBSCAN_r(handle, collection, d, minPoints);
+    break;
+  case c_GEOS_CLUSTER_ENVELOPE_DISTANCE:
+    clusterInfo = GEOSClusterEnvelopeDistance_r(handle, collection, d);
+    break;
+  case c_GEOS_CLUSTER_ENVELOPE_INTERSECTS:
+    clusterInfo = GEOSClusterEnvelopeIntersects_r(handle, collection);
+    break;
+  case c_GEOS_CLUSTER_GEOMETRY_DISTANCE:
+    clusterInfo = GEOSClusterGeometryDistance_r(handle, collection, d);
+    break;
+  case c_GEOS_CLUSTER_GEOMETRY_INTERSECTS:
+    clusterInfo = GEOSClusterGeometryIntersects_r(handle, collection);
+    break;
+  }
+  GEOSGeom_destroy_r(handle, collection);
+  if (clusterInfo == NULL) {
+    return -1;
+  }
+
+  size_t numClusters = GEOSClusterInfo_getNumClusters_r(handle, clusterInfo);
+  size_t *clustersForInputs =
+      GEOSClusterInfo_getClustersForInputs_r(handle, clusterInfo);
+  if (clustersForInputs == NULL) {
+    GEOSClusterInfo_destroy_r(handle, clusterInfo);
+    return -1;
+  }
+  for (unsigned int i = 0; i < ngeoms; ++i) {
+    clusterIDs[i] =
+        clustersForInputs[i] < numClusters ? (int)clustersForInputs[i] : -1;
+  }
+  GEOSFree_r(handle, clustersForInputs);
+  GEOSClusterInfo_destroy_r(handle, clusterInfo);
+  return (int)numClusters;
+#else
+  return -1;
+#endif
+}
+
+// c_GEOSGridIntersectionFractions_r calls GEOSGridIntersectionFractions_r,
+// which was added in GEOS 3.14. With earlier versions it always returns 0.
+int c_GEOSGridIntersectionFractions_r(GEOSContextHandle_t handle,
+                                      const GEOSGeometry *g, double xmin,
+                                      double ymin, double xmax, double ymax,
+                                      unsigned int nx, unsigned int ny,
+                                      float *buf) {
+#if GEOS_VERSION_MAJOR > 3 ||                                                  \
+    (GEOS_VERSION_MAJOR == 3 && GEOS_VERSION_MINOR >= 14)
+  return GEOSGridIntersectionFractions_r(handle, g, xmin, ymin, xmax, ymax, nx,
+                                         ny, buf);
+#else
+  return 0;
+#endif
+}
+
+// c_GEOSHilbertCode_r sets code to the Hilbert code of the center of g's
+// envelope at level relative to the extent (minX, minY, maxX, maxY). It returns
+// 0 on exception.
+int c_GEOSHilbertCode_r(GEOSContextHandle_t handle, const GEOSGeometry *g,
+                        double minX, double minY, double maxX, double maxY,
+                        unsigned int level, unsigned int *code) {
+  GEOSGeometry *extent =
+      GEOSGeom_createRectangle_r(handle, minX, minY, maxX, maxY);
+  if (extent == NULL) {
+    return 0;
+  }
+  int result = GEOSHilbertCode_r(handle, g, extent, level, code);
+  GEOSGeom_destroy_r(handle, extent);
+  return result;
+}
+
 // c_GEOSGeomBounds_r extends bounds to include g.
 void c_GEOSGeomBounds_r(GEOSContextHandle_t handle, const GEOSGeometry *g,
                         double *minX, double *minY, double *maxX,
@@ -92,6 +204,98 @@ void c_GEOSGeomBounds_r(GEOSContextHandle_t handle, const GEOSGeometry *g,
   }
 }
 
+// c_GEOSGeomBounds3D_r extends bounds to include g, ignoring NaN Z
+// coordinates.
+void c_GEOSGeomBounds3D_r(GEOSContextHandle_t handle, const GEOSGeometry *g,
+                          double *minX, double *minY, double *minZ,
+                          double *maxX, double *maxY, double *maxZ) {
+  if (GEOSisEmpty_r(handle, g)) {
+    return;
+  }
+
+  switch (GEOSGeomTypeId_r(handle, g)) {
+  case GEOS_POINT: {
+    double x;
+    GEOSGeomGetX_r(handle, g, &x);
+    if (x < *minX) {
+      *minX = x;
+    }
+    if (x > *maxX) {
+      *maxX = x;
+    }
+    double y;
+    GEOSGeomGetY_r(handle, g, &y);
+    if (y < *minY) {
+      *minY = y;
+    }
+    if (y > *maxY) {
+      *maxY = y;
+    }
+    double z;
+    GEOSGeomGetZ_r(handle, g, &z);
+    if (z < *minZ) {
+      *minZ = z;
+    }
+    if (z > *maxZ) {
+      *maxZ = z;
+    }
+  } break;
+  case GEOS_LINESTRING:
+    // fallthrough
+  case GEOS_LINEARRING: {
+    const GEOSCoordSequence *s = GEOSGeom_getCoordSeq_r(handle, g);
+    unsigned int size;
+    GEOSCoordSeq_getSize_r(handle, s, &size);
+    for (int i = 0; i < size; ++i) {
+      double x;
+      GEOSCoordSeq_getX_r(handle, s, i, &x);
+      if (x < *minX) {
+        *minX = x;
+      }
+      if (x > *maxX) {
+        *maxX = x;
+      }
+      double y;
+      GEOSCoordSeq_getY_r(handle, s, i, &y);
+      if (y < *minY) {
+        *minY = y;
+      }
+      if (y > *maxY) {
+        *maxY = y;
+      }
+      double z;
+      GEOSCoordSeq_getZ_r(handle, s, i, &z);
+      if (z < *minZ) {
+        *minZ = z;
+      }
+      if (z > *maxZ) {
+        *maxZ = z;
+      }
+    }
+  } break;
+  case GEOS_POLYGON:
+    c_GEOSGeomBounds3D_r(handle, GEOSGetExteriorRing_r(handle, g), minX, minY,
+                         minZ, maxX, maxY, maxZ);
+    for (int i = 0, n = GEOSGetNumInteriorRings_r(handle, g); i < n; ++i) {
+      c_GEOSGeomBounds3D_r(handle, GEOSGetInteriorRingN_r(handle, g, i), minX,
+                           minY, minZ, maxX, maxY, maxZ);
+    }
+    break;
+  case GEOS_MULTIPOINT:
+    // fallthrough
+  case GEOS_MULTILINESTRING:
+    // fallthrough
+  case GEOS_MULTIPOLYGON:
+    // fallthrough
+  case GEOS_GEOMETRYCOLLECTION:
+    for (int i = 0, n = GEOSGetNumGeometries_r(handle, g); i < n; ++i) {
+      c_GEOSGeomBounds3D_r(handle, GEOSGetGeometryN_r(handle, g, i), minX,
+                           minY, minZ, maxX, maxY, maxZ);
+    }
+    break;
+  }
+}
+
 // c_GEOSGeomGetInfo_r returns information about g. It returns 0 on any
 // exception, 1 otherwise.
 int c_GEOSGeomGetInfo_r(GEOSContextHandle_t handle, const GEOSGeometry *g,
@@ -124,6 +328,64 @@ int c_GEOSGeomGetInfo_r(GEOSContextHandle_t handle, const GEOSGeometry *g,
   return 1;
 }
 
+// c_GEOSPreparedRelate_r returns the DE-9IM intersection matrix of pg1 and g2.
+// GEOSPreparedRelate_r was added in GEOS 3.13, so with earlier versions g1, the
+// geometry from which pg1 was prepared, is used instead.
+char *c_GEOSPreparedRelate_r(GEOSContextHandle_t handle,
+                             const GEOSPreparedGeometry *pg1,
+                             const GEOSGeometry *g1, const GEOSGeometry *g2) {
+#if GEOS_VERSION_MAJOR > 3 ||                                                  \
+    (GEOS_VERSION_MAJOR == 3 && GEOS_VERSION_MINOR >= 13)
+  return GEOSPreparedRelate_r(handle, pg1, g2);
+#else
+  return GEOSRelate_r(handle, g1, g2);
+#endif
+}
+
+// c_GEOSPreparedRelatePattern_r returns whether the DE-9IM intersection matrix
+// of pg1 and g2 matches pat. GEOSPreparedRelatePattern_r was added in GEOS
+// 3.13, so with earlier versions g1, the geometry from which pg1 was prepared,
+// is used instead.
+char c_GEOSPreparedRelatePattern_r(GEOSContextHandle_t handle,
+                                   const GEOSPreparedGeometry *pg1,
+                                   const GEOSGeometry *g1,
+                                   const GEOSGeometry *g2, const char *pat) {
+#if GEOS_VERSION_MAJOR > 3 ||                                                  \
+    (GEOS_VERSION_MAJOR == 3 && GEOS_VERSION_MINOR >= 13)
+  return GEOSPreparedRelatePattern_r(handle, pg1, g2, pat);
+#else
+  return GEOSRelatePattern_r(handle, g1, g2, pat);
+#endif
+}
+
+// c_GEOSWKBReader_setFixStructure_r calls GEOSWKBReader_setFixStructure_r,
+// which was added in GEOS 3.13. It returns 1 on success and 0 with earlier
+// versions.
+int c_GEOSWKBReader_setFixStructure_r(GEOSContextHandle_t handle,
+                                      GEOSWKBReader *reader, char doFix) {
+#if GEOS_VERSION_MAJOR > 3 ||                                                  \
+    (GEOS_VERSION_MAJOR == 3 && GEOS_VERSION_MINOR >= 13)
+  GEOSWKBReader_setFixStructure_r(handle, reader, doFix);
+  return 1;
+#else
+  return 0;
+#endif
+}
+
+// c_GEOSWKTReader_setFixStructure_r calls GEOSWKTReader_setFixStructure_r,
+// which was added in GEOS 3.13. It returns 1 on success and 0 with earlier
+// versions.
+int c_GEOSWKTReader_setFixStructure_r(GEOSContextHandle_t handle,
+                                      GEOSWKTReader *reader, char doFix) {
+#if GEOS_VERSION_MAJOR > 3 ||                                                  \
+    (GEOS_VERSION_MAJOR == 3 && GEOS_VERSION_MINOR >= 13)
+  GEOSWKTReader_setFixStructure_r(handle, reader, doFix);
+  return 1;
+#else
+  return 0;
+#endif
+}
+
 void c_errorMessageHandler(const char *message, void *userdata) {
   void go_errorMessageHandler(const char *, void *);
   go_errorMessageHandler(message, userdata);
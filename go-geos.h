@@ -19,15 +19,49 @@ enum GEOSVoronoiFlags {
 };
 #endif
 
+enum c_GEOSClusterMethod {
+  c_GEOS_CLUSTER_DBSCAN,
+  c_GEOS_CLUSTER_ENVELOPE_DISTANCE,
+  c_GEOS_CLUSTER_ENVELOPE_INTERSECTS,
+  c_GEOS_CLUSTER_GEOMETRY_DISTANCE,
+  c_GEOS_CLUSTER_GEOMETRY_INTERSECTS,
+};
+
+int c_GEOSCluster_r(GEOSContextHandle_t handle,
+                    const GEOSGeometry *const *geoms, unsigned int ngeoms,
+                    enum c_GEOSClusterMethod method, double d,
+                    unsigned int minPoints, int *clusterIDs);
 uintptr_t c_GEOSGeom_getUserData_r(GEOSContextHandle_t handle,
                                    const GEOSGeometry *g);
 void c_GEOSGeom_setUserData_r(GEOSContextHandle_t handle, GEOSGeometry *g,
                               uintptr_t userdata);
 void c_GEOSGeomBounds_r(GEOSContextHandle_t handle, const GEOSGeometry *g,
                         double *minX, double *minY, double *maxX, double *maxY);
+void c_GEOSGeomBounds3D_r(GEOSContextHandle_t handle, const GEOSGeometry *g,
+                          double *minX, double *minY, double *minZ,
+                          double *maxX, double *maxY, double *maxZ);
 int c_GEOSGeomGetInfo_r(GEOSContextHandle_t handle, const GEOSGeometry *g,
                         int *typeID, int *numGeometries, int *numPoints,
                         int *numInteriorRings);
+int c_GEOSGridIntersectionFractions_r(GEOSContextHandle_t handle,
+                                      const GEOSGeometry *g, double xmin,
+                                      double ymin, double xmax, double ymax,
+                                      unsigned int nx, unsigned int ny,
+                                      float *buf);
+int c_GEOSHilbertCode_r(GEOSContextHandle_t handle, const GEOSGeometry *g,
+                        double minX, double minY, double maxX, double maxY,
+                        unsigned int level, unsigned int *code);
+char *c_GEOSPreparedRelate_r(GEOSContextHandle_t handle,
+                             const GEOSPreparedGeometry *pg1,
+                             const GEOSGeometry *g1, const GEOSGeometry *g2);
+char c_GEOSPreparedRelatePattern_r(GEOSContextHandle_t handle,
+                                   const GEOSPreparedGeometry *pg1,
+                                   const GEOSGeometry *g1,
+                                   const GEOSGeometry *g2, const char *pat);
+int c_GEOSWKBReader_setFixStructure_r(GEOSContextHandle_t handle,
+                                      GEOSWKBReader *reader, char doFix);
+int c_GEOSWKTReader_setFixStructure_r(GEOSContextHandle_t handle,
+                                      GEOSWKTReader *reader, char doFix);
 void c_errorMessageHandler(const char *message, void *userdata);
 GEOSCoordSequence *c_newGEOSCoordSeqFromFlatCoords_r(GEOSContextHandle_t handle,
                                                      unsigned int size,
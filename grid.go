@@ -0,0 +1,73 @@
+package geos
+
+// #include "go-geos.h"
+import "C"
+
+// GridIntersectionFractions returns the fraction of each cell of a grid of
+// rows by cols cells covering extent that is covered by g, which must be
+// polygonal. The fractions are in row-major order starting from the cell at
+// the top left (MinX, MaxY) of extent. It requires GEOS 3.14 or later.
+func (g *Geom) GridIntersectionFractions(extent *Box2D, rows, cols int) []float64 {
+	if VersionCompare(3, 14, 0) < 0 {
+		panic(errUnsupportedGEOSVersion)
+	}
+	if rows < 0 || cols < 0 {
+		panic(errGridSizeOutOfRange)
+	}
+	if rows == 0 || cols == 0 {
+		return nil
+	}
+	g.context.mutex.Lock()
+	defer g.context.mutex.Unlock()
+	cFractions := make([]C.float, rows*cols)
+	if C.c_GEOSGridIntersectionFractions_r(g.context.cHandle, g.cGeom, C.double(extent.MinX), C.double(extent.MinY), C.double(extent.MaxX), C.double(extent.MaxY), C.uint(cols), C.uint(rows), &cFractions[0]) == 0 {
+		panic(g.context.err)
+	}
+	fractions := make([]float64, len(cFractions))
+	for i, cFraction := range cFractions {
+		fractions[i] = float64(cFraction)
+	}
+	return fractions
+}
+
+// Rasterize returns a mask of a grid of rows by cols cells covering extent in
+// which each cell is true if g intersects the cell, excluding cells that g
+// only touches at their boundaries, so that polygons that share an edge with a
+// cell do not set it. The mask is in row-major order starting from the cell at
+// the top left (MinX, MaxY) of extent.
+func (g *Geom) Rasterize(extent *Box2D, rows, cols int) []bool {
+	if rows < 0 || cols < 0 {
+		panic(errGridSizeOutOfRange)
+	}
+	if rows == 0 || cols == 0 {
+		return nil
+	}
+	mask := make([]bool, rows*cols)
+	bounds := g.Bounds()
+	if !bounds.Intersects(extent) {
+		return mask
+	}
+	prepGeom := g.Prepare()
+	cellWidth := extent.Width() / float64(cols)
+	cellHeight := extent.Height() / float64(rows)
+	for row := range rows {
+		maxY := extent.MaxY - float64(row)*cellHeight
+		minY := maxY - cellHeight
+		if minY > bounds.MaxY || maxY < bounds.MinY {
+			continue
+		}
+		for col := range cols {
+			minX := extent.MinX + float64(col)*cellWidth
+			maxX := minX + cellWidth
+			if minX > bounds.MaxX || maxX < bounds.MinX {
+				continue
+			}
+			cell := g.context.NewGeomFromBounds(minX, minY, maxX, maxY)
+			m := prepGeom.Relate(cell)
+			mask[row*cols+col] = m[LocationInterior][LocationInterior].isTrue() ||
+				m[LocationInterior][LocationBoundary].isTrue() ||
+				m[LocationBoundary][LocationInterior].isTrue()
+		}
+	}
+	return mask
+}
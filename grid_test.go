@@ -0,0 +1,93 @@
+package geos_test
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+
+	"github.com/twpayne/go-geos"
+)
+
+func TestGeomGridIntersectionFractions(t *testing.T) {
+	if geos.VersionCompare(3, 14, 0) < 0 {
+		t.Skip("GridIntersectionFractions requires GEOS 3.14 or later")
+	}
+	defer runtime.GC() // Exercise finalizers.
+	c := geos.NewContext()
+	g := mustNewGeomFromWKT(t, c, "POLYGON ((0 0, 1.5 0, 1.5 2, 0 2, 0 0))")
+	extent := geos.NewBox2D(0, 0, 2, 2)
+	assert.Equal(t, []float64{1, 0.5, 1, 0.5}, g.GridIntersectionFractions(extent, 2, 2))
+	assert.Zero(t, g.GridIntersectionFractions(extent, 0, 2))
+	assert.Panics(t, func() { g.GridIntersectionFractions(extent, -1, 2) })
+}
+
+func TestGeomRasterize(t *testing.T) {
+	for _, tc := range []struct {
+		name         string
+		wkt          string
+		expectedMask []bool
+	}{
+		{
+			name: "polygon",
+			wkt:  "POLYGON ((0 0, 1 0, 1 2, 0 2, 0 0))",
+			expectedMask: []bool{
+				false, false, false,
+				true, false, false,
+				true, false, false,
+			},
+		},
+		{
+			name: "triangle",
+			wkt:  "POLYGON ((0.5 0.5, 2.5 0.5, 0.5 2.5, 0.5 0.5))",
+			expectedMask: []bool{
+				true, false, false,
+				true, true, false,
+				true, true, true,
+			},
+		},
+		{
+			name: "point",
+			wkt:  "POINT (2.5 0.5)",
+			expectedMask: []bool{
+				false, false, false,
+				false, false, false,
+				false, false, true,
+			},
+		},
+		{
+			name: "point_on_edge",
+			wkt:  "POINT (2 0.5)",
+			expectedMask: []bool{
+				false, false, false,
+				false, false, false,
+				false, true, true,
+			},
+		},
+		{
+			name: "line",
+			wkt:  "LINESTRING (0.5 2.5, 2.5 2.5)",
+			expectedMask: []bool{
+				true, true, true,
+				false, false, false,
+				false, false, false,
+			},
+		},
+		{
+			name: "outside",
+			wkt:  "POINT (5 5)",
+			expectedMask: []bool{
+				false, false, false,
+				false, false, false,
+				false, false, false,
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			defer runtime.GC() // Exercise finalizers.
+			c := geos.NewContext()
+			g := mustNewGeomFromWKT(t, c, tc.wkt)
+			assert.Equal(t, tc.expectedMask, g.Rasterize(geos.NewBox2D(0, 0, 3, 3), 3, 3))
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package geos
+
+// #include "go-geos.h"
+import "C"
+
+import (
+	"cmp"
+	"math"
+	"slices"
+)
+
+// HilbertCodeMaxLevel is the maximum level of a Hilbert code.
+const HilbertCodeMaxLevel = 16
+
+// HilbertCode returns the Hilbert code of the center of g's envelope at level,
+// between 0 and HilbertCodeMaxLevel, relative to extent.
+func (g *Geom) HilbertCode(extent *Box2D, level int) uint32 {
+	if level < 0 || level > HilbertCodeMaxLevel {
+		panic(errLevelOutOfRange)
+	}
+	g.context.mutex.Lock()
+	defer g.context.mutex.Unlock()
+	var code C.uint
+	if C.c_GEOSHilbertCode_r(g.context.cHandle, g.cGeom, C.double(extent.MinX), C.double(extent.MinY), C.double(extent.MaxX), C.double(extent.MaxY), C.uint(level), &code) == 0 {
+		panic(g.context.err)
+	}
+	return uint32(code)
+}
+
+// SortByHilbert sorts geoms in place by the Hilbert codes of the centers of
+// their envelopes relative to the extent of all of geoms, so that geometries
+// that are close to each other are likely to be close to each other in geoms.
+// Empty geometries are sorted last.
+func SortByHilbert(geoms []*Geom) {
+	type hilbertGeom struct {
+		geom  *Geom
+		empty bool
+		code  uint32
+	}
+
+	hilbertGeoms := make([]hilbertGeom, len(geoms))
+	extent := NewBox2DEmpty()
+	for i, geom := range geoms {
+		bounds := geom.Bounds()
+		hilbertGeoms[i].geom = geom
+		hilbertGeoms[i].empty = bounds.IsEmpty()
+		if hilbertGeoms[i].empty {
+			continue
+		}
+		extent.MinX = math.Min(extent.MinX, bounds.MinX)
+		extent.MinY = math.Min(extent.MinY, bounds.MinY)
+		extent.MaxX = math.Max(extent.MaxX, bounds.MaxX)
+		extent.MaxY = math.Max(extent.MaxY, bounds.MaxY)
+	}
+	// Pad a degenerate extent, as zero-sized grid cells give undefined codes.
+	if !extent.IsEmpty() {
+		if extent.MinX == extent.MaxX {
+			extent.MinX -= 0.5
+			extent.MaxX += 0.5
+		}
+		if extent.MinY == extent.MaxY {
+			extent.MinY -= 0.5
+			extent.MaxY += 0.5
+		}
+	}
+	for i := range hilbertGeoms {
+		if !hilbertGeoms[i].empty {
+			hilbertGeoms[i].code = hilbertGeoms[i].geom.HilbertCode(extent, HilbertCodeMaxLevel)
+		}
+	}
+
+	slices.SortStableFunc(hilbertGeoms, func(a, b hilbertGeom) int {
+		switch {
+		case a.empty && b.empty:
+			return 0
+		case a.empty:
+			return 1
+		case b.empty:
+			return -1
+		default:
+			return cmp.Compare(a.code, b.code)
+		}
+	})
+	for i := range hilbertGeoms {
+		geoms[i] = hilbertGeoms[i].geom
+	}
+}
@@ -0,0 +1,85 @@
+package geos_test
+
+import (
+	"runtime"
+	"slices"
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+
+	"github.com/twpayne/go-geos"
+)
+
+func TestGeomHilbertCode(t *testing.T) {
+	defer runtime.GC() // Exercise finalizers.
+	c := geos.NewContext()
+	extent := geos.NewBox2D(0, 0, 1, 1)
+	assert.Equal(t, 0, c.NewPoint([]float64{0, 0}).HilbertCode(extent, 1))
+	assert.Equal(t, 2, c.NewPoint([]float64{1, 1}).HilbertCode(extent, 1))
+	assert.Equal(t, 0, c.NewPoint([]float64{0, 0}).HilbertCode(extent, geos.HilbertCodeMaxLevel))
+	assert.Panics(t, func() { c.NewPoint([]float64{0, 0}).HilbertCode(extent, -1) })
+	assert.Panics(t, func() { c.NewPoint([]float64{0, 0}).HilbertCode(extent, geos.HilbertCodeMaxLevel+1) })
+}
+
+func TestSortByHilbert(t *testing.T) {
+	defer runtime.GC() // Exercise finalizers.
+	c := geos.NewContext()
+	geoms := []*geos.Geom{
+		mustNewGeomFromWKT(t, c, "POINT (10 10)"),
+		mustNewGeomFromWKT(t, c, "POINT (0.1 0.1)"),
+		mustNewGeomFromWKT(t, c, "POINT EMPTY"),
+		mustNewGeomFromWKT(t, c, "POINT (9.9 10)"),
+		mustNewGeomFromWKT(t, c, "POINT (0 0)"),
+	}
+	geos.SortByHilbert(geoms)
+	actualWKTs := make([]string, 0, len(geoms))
+	for _, g := range geoms {
+		actualWKTs = append(actualWKTs, g.ToWKT())
+	}
+	assert.Equal(t, "POINT (0 0)", actualWKTs[0])
+	assert.Equal(t, "POINT (0.1 0.1)", actualWKTs[1])
+	assert.SliceContains(t, []string{"POINT (10 10)", "POINT (9.9 10)"}, actualWKTs[2])
+	assert.SliceContains(t, []string{"POINT (10 10)", "POINT (9.9 10)"}, actualWKTs[3])
+	assert.NotEqual(t, actualWKTs[2], actualWKTs[3])
+	assert.Equal(t, "POINT EMPTY", actualWKTs[4])
+
+	geos.SortByHilbert(nil)
+}
+
+func TestSortByHilbertSingle(t *testing.T) {
+	defer runtime.GC() // Exercise finalizers.
+	c := geos.NewContext()
+	geoms := []*geos.Geom{
+		mustNewGeomFromWKT(t, c, "POINT (1 2)"),
+	}
+	geos.SortByHilbert(geoms)
+	assert.Equal(t, "POINT (1 2)", geoms[0].ToWKT())
+}
+
+func TestSortByHilbertCollinear(t *testing.T) {
+	defer runtime.GC() // Exercise finalizers.
+	c := geos.NewContext()
+	for _, wkts := range [][]string{
+		{"POINT (0 0)", "POINT (1 0)", "POINT (2 0)", "POINT (3 0)"},
+		{"POINT (0 0)", "POINT (0 1)", "POINT (0 2)", "POINT (0 3)"},
+	} {
+		// The sorted order must not depend on the input order, which it
+		// would if the degenerate extent gave every geometry the same code.
+		var sortedWKTs [2][]string
+		for i := range sortedWKTs {
+			geoms := make([]*geos.Geom, 0, len(wkts))
+			for _, wkt := range wkts {
+				geoms = append(geoms, mustNewGeomFromWKT(t, c, wkt))
+			}
+			if i == 1 {
+				slices.Reverse(geoms)
+			}
+			geos.SortByHilbert(geoms)
+			for _, g := range geoms {
+				sortedWKTs[i] = append(sortedWKTs[i], g.ToWKT())
+			}
+		}
+		assert.Equal(t, sortedWKTs[0], sortedWKTs[1])
+		assert.Equal(t, wkts, slices.Sorted(slices.Values(sortedWKTs[0])))
+	}
+}
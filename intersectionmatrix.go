@@ -0,0 +1,259 @@
+package geos
+
+import (
+	"fmt"
+	"strings"
+)
+
+// A Location is a topological location relative to a geometry.
+type Location int
+
+// Locations.
+const (
+	LocationInterior Location = 0
+	LocationBoundary Location = 1
+	LocationExterior Location = 2
+)
+
+// A Dimension is a topological dimension, or a dimension value in a DE-9IM
+// intersection matrix or pattern.
+type Dimension int
+
+// Dimensions.
+const (
+	DimensionDontCare Dimension = -3
+	DimensionTrue     Dimension = -2
+	DimensionFalse    Dimension = -1
+	DimensionPoint    Dimension = 0
+	DimensionLine     Dimension = 1
+	DimensionArea     Dimension = 2
+)
+
+// An IntersectionMatrix is a DE-9IM intersection matrix, indexed by the
+// Location in the first geometry and then the Location in the second geometry.
+type IntersectionMatrix [3][3]Dimension
+
+// ParseIntersectionMatrix parses an intersection matrix from its nine
+// character string representation, as returned by Geom.Relate.
+func ParseIntersectionMatrix(s string) (IntersectionMatrix, error) {
+	var m IntersectionMatrix
+	if len(s) != 9 {
+		return m, fmt.Errorf("%q: %w", s, errInvalidIntersectionMatrix)
+	}
+	for i := range 9 {
+		d, ok := parseDimension(s[i])
+		if !ok || d == DimensionTrue || d == DimensionDontCare {
+			return m, fmt.Errorf("%q: %w", s, errInvalidIntersectionMatrix)
+		}
+		m[i/3][i%3] = d
+	}
+	return m, nil
+}
+
+// Get returns the dimension of the intersection of the locA of the first
+// geometry and locB of the second geometry.
+func (m IntersectionMatrix) Get(locA, locB Location) Dimension {
+	return m[locA][locB]
+}
+
+// IsContains returns if m matches the pattern for contains, T*****FF*.
+func (m IntersectionMatrix) IsContains() bool {
+	return m[LocationInterior][LocationInterior].isTrue() &&
+		m[LocationExterior][LocationInterior] == DimensionFalse &&
+		m[LocationExterior][LocationBoundary] == DimensionFalse
+}
+
+// IsCoveredBy returns if m matches any of the patterns for covered by,
+// T*F**F***, *TF**F***, **FT*F***, or **F*TF***.
+func (m IntersectionMatrix) IsCoveredBy() bool {
+	return (m[LocationInterior][LocationInterior].isTrue() ||
+		m[LocationInterior][LocationBoundary].isTrue() ||
+		m[LocationBoundary][LocationInterior].isTrue() ||
+		m[LocationBoundary][LocationBoundary].isTrue()) &&
+		m[LocationInterior][LocationExterior] == DimensionFalse &&
+		m[LocationBoundary][LocationExterior] == DimensionFalse
+}
+
+// IsCovers returns if m matches any of the patterns for covers, T*****FF*,
+// *T****FF*, ***T**FF*, or ****T*FF*.
+func (m IntersectionMatrix) IsCovers() bool {
+	return (m[LocationInterior][LocationInterior].isTrue() ||
+		m[LocationInterior][LocationBoundary].isTrue() ||
+		m[LocationBoundary][LocationInterior].isTrue() ||
+		m[LocationBoundary][LocationBoundary].isTrue()) &&
+		m[LocationExterior][LocationInterior] == DimensionFalse &&
+		m[LocationExterior][LocationBoundary] == DimensionFalse
+}
+
+// IsCrosses returns if m represents two geometries of dimensions dimA and dimB
+// that cross.
+func (m IntersectionMatrix) IsCrosses(dimA, dimB Dimension) bool {
+	switch {
+	case dimA < dimB && dimA >= DimensionPoint && dimB <= DimensionArea:
+		return m[LocationInterior][LocationInterior].isTrue() && m[LocationInterior][LocationExterior].isTrue()
+	case dimA > dimB && dimB >= DimensionPoint && dimA <= DimensionArea:
+		return m[LocationInterior][LocationInterior].isTrue() && m[LocationExterior][LocationInterior].isTrue()
+	case dimA == DimensionLine && dimB == DimensionLine:
+		return m[LocationInterior][LocationInterior] == DimensionPoint
+	default:
+		return false
+	}
+}
+
+// IsDisjoint returns if m matches the pattern for disjoint, FF*FF****.
+func (m IntersectionMatrix) IsDisjoint() bool {
+	return m[LocationInterior][LocationInterior] == DimensionFalse &&
+		m[LocationInterior][LocationBoundary] == DimensionFalse &&
+		m[LocationBoundary][LocationInterior] == DimensionFalse &&
+		m[LocationBoundary][LocationBoundary] == DimensionFalse
+}
+
+// IsEquals returns if m represents two topologically equal geometries of
+// dimensions dimA and dimB.
+func (m IntersectionMatrix) IsEquals(dimA, dimB Dimension) bool {
+	if dimA != dimB {
+		return false
+	}
+	return m[LocationInterior][LocationInterior].isTrue() &&
+		m[LocationInterior][LocationExterior] == DimensionFalse &&
+		m[LocationBoundary][LocationExterior] == DimensionFalse &&
+		m[LocationExterior][LocationInterior] == DimensionFalse &&
+		m[LocationExterior][LocationBoundary] == DimensionFalse
+}
+
+// IsIntersects returns if m does not match the pattern for disjoint.
+func (m IntersectionMatrix) IsIntersects() bool {
+	return !m.IsDisjoint()
+}
+
+// IsOverlaps returns if m represents two geometries of dimensions dimA and
+// dimB that overlap.
+func (m IntersectionMatrix) IsOverlaps(dimA, dimB Dimension) bool {
+	switch {
+	case dimA == DimensionPoint && dimB == DimensionPoint, dimA == DimensionArea && dimB == DimensionArea:
+		return m[LocationInterior][LocationInterior].isTrue() &&
+			m[LocationInterior][LocationExterior].isTrue() &&
+			m[LocationExterior][LocationInterior].isTrue()
+	case dimA == DimensionLine && dimB == DimensionLine:
+		return m[LocationInterior][LocationInterior] == DimensionLine &&
+			m[LocationInterior][LocationExterior].isTrue() &&
+			m[LocationExterior][LocationInterior].isTrue()
+	default:
+		return false
+	}
+}
+
+// IsTouches returns if m represents two geometries of dimensions dimA and dimB
+// that touch.
+func (m IntersectionMatrix) IsTouches(dimA, dimB Dimension) bool {
+	if dimA == DimensionPoint && dimB == DimensionPoint {
+		return false
+	}
+	return m[LocationInterior][LocationInterior] == DimensionFalse &&
+		(m[LocationInterior][LocationBoundary].isTrue() ||
+			m[LocationBoundary][LocationInterior].isTrue() ||
+			m[LocationBoundary][LocationBoundary].isTrue())
+}
+
+// IsWithin returns if m matches the pattern for within, T*F**F***.
+func (m IntersectionMatrix) IsWithin() bool {
+	return m[LocationInterior][LocationInterior].isTrue() &&
+		m[LocationInterior][LocationExterior] == DimensionFalse &&
+		m[LocationBoundary][LocationExterior] == DimensionFalse
+}
+
+// Matches returns if m matches pattern, a nine character string of the
+// characters 0, 1, 2, F, T, and *. It panics if pattern is invalid.
+func (m IntersectionMatrix) Matches(pattern string) bool {
+	if len(pattern) != 9 {
+		panic(errInvalidIntersectionMatrixPattern)
+	}
+	for i := range 9 {
+		p, ok := parseDimension(pattern[i])
+		if !ok {
+			panic(errInvalidIntersectionMatrixPattern)
+		}
+		if !m[i/3][i%3].matches(p) {
+			return false
+		}
+	}
+	return true
+}
+
+// String returns m as a nine character string.
+func (m IntersectionMatrix) String() string {
+	var sb strings.Builder
+	sb.Grow(9)
+	for i := range 3 {
+		for j := range 3 {
+			sb.WriteString(m[i][j].String())
+		}
+	}
+	return sb.String()
+}
+
+// String returns d as a single character string.
+func (d Dimension) String() string {
+	switch d {
+	case DimensionDontCare:
+		return "*"
+	case DimensionTrue:
+		return "T"
+	case DimensionFalse:
+		return "F"
+	case DimensionPoint:
+		return "0"
+	case DimensionLine:
+		return "1"
+	case DimensionArea:
+		return "2"
+	default:
+		return fmt.Sprintf("Dimension(%d)", int(d))
+	}
+}
+
+// isTrue returns if d is a non-empty dimension.
+func (d Dimension) isTrue() bool {
+	return d >= DimensionPoint || d == DimensionTrue
+}
+
+// matches returns if d matches the pattern dimension p.
+func (d Dimension) matches(p Dimension) bool {
+	switch p {
+	case DimensionDontCare:
+		return true
+	case DimensionTrue:
+		return d.isTrue()
+	default:
+		return d == p
+	}
+}
+
+// parseDimension parses a dimension from its single character representation.
+func parseDimension(c byte) (Dimension, bool) {
+	switch c {
+	case '*':
+		return DimensionDontCare, true
+	case 'T', 't':
+		return DimensionTrue, true
+	case 'F', 'f':
+		return DimensionFalse, true
+	case '0':
+		return DimensionPoint, true
+	case '1':
+		return DimensionLine, true
+	case '2':
+		return DimensionArea, true
+	default:
+		return 0, false
+	}
+}
+
+// mustParseIntersectionMatrix parses an intersection matrix returned by GEOS.
+func mustParseIntersectionMatrix(s string) IntersectionMatrix {
+	m, err := ParseIntersectionMatrix(s)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
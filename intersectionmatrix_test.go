@@ -0,0 +1,154 @@
+package geos_test
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+
+	"github.com/twpayne/go-geos"
+)
+
+func TestParseIntersectionMatrix(t *testing.T) {
+	m, err := geos.ParseIntersectionMatrix("F0FFFF102")
+	assert.NoError(t, err)
+	assert.Equal(t, geos.DimensionFalse, m.Get(geos.LocationInterior, geos.LocationInterior))
+	assert.Equal(t, geos.DimensionPoint, m.Get(geos.LocationInterior, geos.LocationBoundary))
+	assert.Equal(t, geos.DimensionLine, m.Get(geos.LocationExterior, geos.LocationInterior))
+	assert.Equal(t, geos.DimensionArea, m.Get(geos.LocationExterior, geos.LocationExterior))
+	assert.Equal(t, "F0FFFF102", m.String())
+
+	for _, s := range []string{"", "F0FFFF10", "F0FFFF1022", "T0FFFF102", "*0FFFF102", "F0FFFF103"} {
+		_, err := geos.ParseIntersectionMatrix(s)
+		assert.Error(t, err)
+	}
+}
+
+func TestIntersectionMatrixPredicates(t *testing.T) {
+	for _, tc := range []struct {
+		name               string
+		s                  string
+		dimA               geos.Dimension
+		dimB               geos.Dimension
+		expectedContains   bool
+		expectedCoveredBy  bool
+		expectedCovers     bool
+		expectedCrosses    bool
+		expectedDisjoint   bool
+		expectedEquals     bool
+		expectedIntersects bool
+		expectedOverlaps   bool
+		expectedTouches    bool
+		expectedWithin     bool
+		expectedMatches    []string
+		expectedMismatches []string
+	}{
+		{
+			name:               "contains",
+			s:                  "212FF1FF2",
+			dimA:               geos.DimensionArea,
+			dimB:               geos.DimensionArea,
+			expectedContains:   true,
+			expectedCovers:     true,
+			expectedIntersects: true,
+			expectedMatches:    []string{"T*****FF*", "212FF1FF2", "*********"},
+			expectedMismatches: []string{"T*F**F***", "FF*FF****"},
+		},
+		{
+			name:               "within",
+			s:                  "2FF1FF212",
+			dimA:               geos.DimensionArea,
+			dimB:               geos.DimensionArea,
+			expectedCoveredBy:  true,
+			expectedIntersects: true,
+			expectedWithin:     true,
+			expectedMatches:    []string{"T*F**F***"},
+		},
+		{
+			name:               "equals",
+			s:                  "2FFF1FFF2",
+			dimA:               geos.DimensionArea,
+			dimB:               geos.DimensionArea,
+			expectedContains:   true,
+			expectedCoveredBy:  true,
+			expectedCovers:     true,
+			expectedEquals:     true,
+			expectedIntersects: true,
+			expectedWithin:     true,
+		},
+		{
+			name:               "touches",
+			s:                  "FF2F11212",
+			dimA:               geos.DimensionArea,
+			dimB:               geos.DimensionArea,
+			expectedIntersects: true,
+			expectedTouches:    true,
+		},
+		{
+			name:               "overlaps",
+			s:                  "212101212",
+			dimA:               geos.DimensionArea,
+			dimB:               geos.DimensionArea,
+			expectedIntersects: true,
+			expectedOverlaps:   true,
+		},
+		{
+			name:               "crosses",
+			s:                  "0F1FF0102",
+			dimA:               geos.DimensionLine,
+			dimB:               geos.DimensionLine,
+			expectedCrosses:    true,
+			expectedIntersects: true,
+		},
+		{
+			name:             "disjoint",
+			s:                "FF2FF1212",
+			dimA:             geos.DimensionArea,
+			dimB:             geos.DimensionArea,
+			expectedDisjoint: true,
+			expectedMatches:  []string{"FF*FF****", "ff*ff****"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			m, err := geos.ParseIntersectionMatrix(tc.s)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedContains, m.IsContains())
+			assert.Equal(t, tc.expectedCoveredBy, m.IsCoveredBy())
+			assert.Equal(t, tc.expectedCovers, m.IsCovers())
+			assert.Equal(t, tc.expectedCrosses, m.IsCrosses(tc.dimA, tc.dimB))
+			assert.Equal(t, tc.expectedDisjoint, m.IsDisjoint())
+			assert.Equal(t, tc.expectedEquals, m.IsEquals(tc.dimA, tc.dimB))
+			assert.Equal(t, tc.expectedIntersects, m.IsIntersects())
+			assert.Equal(t, tc.expectedOverlaps, m.IsOverlaps(tc.dimA, tc.dimB))
+			assert.Equal(t, tc.expectedTouches, m.IsTouches(tc.dimA, tc.dimB))
+			assert.Equal(t, tc.expectedWithin, m.IsWithin())
+			for _, pattern := range tc.expectedMatches {
+				assert.True(t, m.Matches(pattern))
+			}
+			for _, pattern := range tc.expectedMismatches {
+				assert.False(t, m.Matches(pattern))
+			}
+			assert.Panics(t, func() { m.Matches("T*****FF") })
+			assert.Panics(t, func() { m.Matches("T*****FFX") })
+		})
+	}
+}
+
+func TestGeomRelateMatrix(t *testing.T) {
+	defer runtime.GC() // Exercise finalizers.
+	c := geos.NewContext()
+	unitSquare := mustNewGeomFromWKT(t, c, "POLYGON ((0 0, 1 0, 1 1, 0 1, 0 0))")
+	middleSquare := mustNewGeomFromWKT(t, c, "POLYGON ((0.25 0.25, 0.75 0.25, 0.75 0.75, 0.25 0.75, 0.25 0.25))")
+	adjacentSquare := mustNewGeomFromWKT(t, c, "POLYGON ((1 0, 2 0, 2 1, 1 1, 1 0))")
+
+	m := unitSquare.RelateMatrix(middleSquare)
+	assert.Equal(t, "212FF1FF2", m.String())
+	assert.True(t, m.IsContains())
+	assert.False(t, m.IsWithin())
+	assert.Equal(t, m, unitSquare.Prepare().Relate(middleSquare))
+
+	m = unitSquare.RelateMatrix(adjacentSquare)
+	assert.Equal(t, "FF2F11212", m.String())
+	assert.True(t, m.IsTouches(geos.DimensionArea, geos.DimensionArea))
+	assert.Equal(t, m, unitSquare.Prepare().Relate(adjacentSquare))
+}
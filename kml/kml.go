@@ -0,0 +1,290 @@
+// Package kml implements GEOS-backed KML documents.
+//
+// Each feature in a Document is written as a Placemark. Feature properties
+// with the names NameProperty, DescriptionProperty, and StyleURLProperty are
+// written as the Placemark's name, description, and styleUrl elements,
+// AltitudeModeProperty and ExtrudeProperty are written as the geometry's
+// altitudeMode and extrude elements, and all other properties are written as
+// ExtendedData.
+package kml
+
+import (
+	"encoding/xml"
+	"fmt"
+	"slices"
+
+	"github.com/twpayne/go-geos"
+	"github.com/twpayne/go-geos/geojson"
+	"github.com/twpayne/go-geos/geometry"
+)
+
+// Namespace is the KML 2.2 namespace.
+const Namespace = "http://www.opengis.net/kml/2.2"
+
+// Feature property names with special meanings.
+const (
+	NameProperty         = "name"
+	DescriptionProperty  = "description"
+	StyleURLProperty     = "styleUrl"
+	AltitudeModeProperty = "altitudeMode"
+	ExtrudeProperty      = "extrude"
+)
+
+// A Document is a KML document.
+type Document struct {
+	Name     string
+	Styles   []*Style
+	Features geojson.FeatureCollection
+}
+
+// A Style is a shared style, referenced from features by a StyleURLProperty of
+// "#" followed by its ID. Colors are hexadecimal in aabbggrr order.
+type Style struct {
+	ID        string     `xml:"id,attr,omitempty"`
+	IconStyle *IconStyle `xml:"IconStyle,omitempty"`
+	LineStyle *LineStyle `xml:"LineStyle,omitempty"`
+	PolyStyle *PolyStyle `xml:"PolyStyle,omitempty"`
+}
+
+// An IconStyle is the style of a Point.
+type IconStyle struct {
+	Color string  `xml:"color,omitempty"`
+	Scale float64 `xml:"scale,omitempty"`
+	Href  string  `xml:"Icon>href,omitempty"`
+}
+
+// A LineStyle is the style of a LineString or the outline of a Polygon.
+type LineStyle struct {
+	Color string  `xml:"color,omitempty"`
+	Width float64 `xml:"width,omitempty"`
+}
+
+// A PolyStyle is the style of a Polygon.
+type PolyStyle struct {
+	Color   string `xml:"color,omitempty"`
+	Fill    *bool  `xml:"fill,omitempty"`
+	Outline *bool  `xml:"outline,omitempty"`
+}
+
+// A container is a kml, Document, or Folder element.
+type container struct {
+	Name       string       `xml:"name,omitempty"`
+	Styles     []*Style     `xml:"Style"`
+	Placemarks []*placemark `xml:"Placemark"`
+	Documents  []*container `xml:"Document"`
+	Folders    []*container `xml:"Folder"`
+}
+
+type placemark struct {
+	ID            string                `xml:"id,attr,omitempty"`
+	Name          string                `xml:"name,omitempty"`
+	Description   string                `xml:"description,omitempty"`
+	StyleURL      string                `xml:"styleUrl,omitempty"`
+	ExtendedData  *extendedData         `xml:"ExtendedData,omitempty"`
+	Point         *geometry.KMLGeometry `xml:"Point,omitempty"`
+	LineString    *geometry.KMLGeometry `xml:"LineString,omitempty"`
+	LinearRing    *geometry.KMLGeometry `xml:"LinearRing,omitempty"`
+	Polygon       *geometry.KMLGeometry `xml:"Polygon,omitempty"`
+	MultiGeometry *geometry.KMLGeometry `xml:"MultiGeometry,omitempty"`
+}
+
+type extendedData struct {
+	Data       []data       `xml:"Data"`
+	SchemaData []schemaData `xml:"SchemaData"`
+}
+
+type data struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value"`
+}
+
+type schemaData struct {
+	SimpleData []simpleData `xml:"SimpleData"`
+}
+
+type simpleData struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:",chardata"`
+}
+
+// MarshalXML implements encoding/xml.Marshaler.
+func (d *Document) MarshalXML(e *xml.Encoder, _ xml.StartElement) error {
+	document := &container{
+		Name:       d.Name,
+		Styles:     d.Styles,
+		Placemarks: make([]*placemark, 0, len(d.Features)),
+	}
+	for _, feature := range d.Features {
+		placemark, err := newPlacemark(feature)
+		if err != nil {
+			return err
+		}
+		document.Placemarks = append(document.Placemarks, placemark)
+	}
+	return e.EncodeElement(&container{
+		Documents: []*container{document},
+	}, xml.StartElement{
+		Name: xml.Name{Local: "kml"},
+		Attr: []xml.Attr{
+			{Name: xml.Name{Local: "xmlns"}, Value: Namespace},
+		},
+	})
+}
+
+// UnmarshalXML implements encoding/xml.Unmarshaler. The start element may be
+// a kml, Document, or Folder element. Placemarks in nested Documents and
+// Folders are flattened into d.Features.
+func (d *Document) UnmarshalXML(decoder *xml.Decoder, start xml.StartElement) error {
+	var c container
+	if err := decoder.DecodeElement(&c, &start); err != nil {
+		return err
+	}
+	d.Name = c.Name
+	if d.Name == "" && len(c.Documents) > 0 {
+		d.Name = c.Documents[0].Name
+	}
+	d.Styles = nil
+	d.Features = nil
+	return d.appendContainer(&c)
+}
+
+// appendContainer appends the styles and features in c and its children to d.
+func (d *Document) appendContainer(c *container) error {
+	d.Styles = append(d.Styles, c.Styles...)
+	for _, placemark := range c.Placemarks {
+		feature, err := placemark.feature()
+		if err != nil {
+			return err
+		}
+		d.Features = append(d.Features, feature)
+	}
+	for _, child := range slices.Concat(c.Documents, c.Folders) {
+		if err := d.appendContainer(child); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func newPlacemark(feature *geojson.Feature) (*placemark, error) {
+	p := &placemark{}
+	if feature.ID != nil {
+		p.ID = fmt.Sprint(feature.ID)
+	}
+
+	var kmlGeometry *geometry.KMLGeometry
+	if feature.Geometry.Geom != nil {
+		kmlGeometry = &geometry.KMLGeometry{
+			Geometry: feature.Geometry,
+		}
+		switch typeID := feature.Geometry.TypeID(); typeID {
+		case geos.TypeIDPoint:
+			p.Point = kmlGeometry
+		case geos.TypeIDLineString:
+			p.LineString = kmlGeometry
+		case geos.TypeIDLinearRing:
+			p.LinearRing = kmlGeometry
+		case geos.TypeIDPolygon:
+			p.Polygon = kmlGeometry
+		case geos.TypeIDMultiPoint, geos.TypeIDMultiLineString, geos.TypeIDMultiPolygon, geos.TypeIDGeometryCollection:
+			p.MultiGeometry = kmlGeometry
+		default:
+			return nil, fmt.Errorf("unsupported type: %s", feature.Geometry.Type())
+		}
+	}
+
+	keys := make([]string, 0, len(feature.Properties))
+	for key := range feature.Properties {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	for _, key := range keys {
+		value := feature.Properties[key]
+		if value == nil {
+			continue
+		}
+		switch key {
+		case NameProperty:
+			p.Name = fmt.Sprint(value)
+		case DescriptionProperty:
+			p.Description = fmt.Sprint(value)
+		case StyleURLProperty:
+			p.StyleURL = fmt.Sprint(value)
+		case AltitudeModeProperty:
+			if kmlGeometry != nil {
+				kmlGeometry.AltitudeMode = fmt.Sprint(value)
+			}
+		case ExtrudeProperty:
+			if kmlGeometry != nil {
+				extrude, ok := value.(bool)
+				if !ok {
+					return nil, fmt.Errorf("%s: %v: invalid value", key, value)
+				}
+				kmlGeometry.Extrude = extrude
+			}
+		default:
+			if p.ExtendedData == nil {
+				p.ExtendedData = &extendedData{}
+			}
+			p.ExtendedData.Data = append(p.ExtendedData.Data, data{
+				Name:  key,
+				Value: fmt.Sprint(value),
+			})
+		}
+	}
+
+	return p, nil
+}
+
+func (p *placemark) feature() (*geojson.Feature, error) {
+	feature := &geojson.Feature{}
+	if p.ID != "" {
+		feature.ID = p.ID
+	}
+
+	properties := make(map[string]any)
+	if p.Name != "" {
+		properties[NameProperty] = p.Name
+	}
+	if p.Description != "" {
+		properties[DescriptionProperty] = p.Description
+	}
+	if p.StyleURL != "" {
+		properties[StyleURLProperty] = p.StyleURL
+	}
+	if p.ExtendedData != nil {
+		for _, data := range p.ExtendedData.Data {
+			properties[data.Name] = data.Value
+		}
+		for _, schemaData := range p.ExtendedData.SchemaData {
+			for _, simpleData := range schemaData.SimpleData {
+				properties[simpleData.Name] = simpleData.Value
+			}
+		}
+	}
+
+	var kmlGeometry *geometry.KMLGeometry
+	for _, g := range []*geometry.KMLGeometry{p.Point, p.LineString, p.LinearRing, p.Polygon, p.MultiGeometry} {
+		if g == nil {
+			continue
+		}
+		if kmlGeometry != nil {
+			return nil, fmt.Errorf("%s: multiple geometries", p.ID)
+		}
+		kmlGeometry = g
+	}
+	if kmlGeometry != nil {
+		feature.Geometry = kmlGeometry.Geometry
+		if kmlGeometry.AltitudeMode != "" {
+			properties[AltitudeModeProperty] = kmlGeometry.AltitudeMode
+		}
+		if kmlGeometry.Extrude {
+			properties[ExtrudeProperty] = true
+		}
+	}
+
+	if len(properties) > 0 {
+		feature.Properties = properties
+	}
+	return feature, nil
+}
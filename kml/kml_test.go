@@ -0,0 +1,147 @@
+package kml_test
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+
+	"github.com/twpayne/go-geos"
+	"github.com/twpayne/go-geos/geojson"
+	"github.com/twpayne/go-geos/geometry"
+	"github.com/twpayne/go-geos/kml"
+)
+
+var (
+	_ xml.Marshaler   = &kml.Document{}
+	_ xml.Unmarshaler = &kml.Document{}
+)
+
+func TestDocument(t *testing.T) {
+	fill := false
+	document := &kml.Document{
+		Name: "document",
+		Styles: []*kml.Style{
+			{
+				ID: "style",
+				LineStyle: &kml.LineStyle{
+					Color: "ff0000ff",
+					Width: 2,
+				},
+				PolyStyle: &kml.PolyStyle{
+					Fill: &fill,
+				},
+			},
+		},
+		Features: geojson.FeatureCollection{
+			{
+				ID:       1,
+				Geometry: *geometry.NewGeometry(geos.NewPoint([]float64{0, 1, 2})),
+				Properties: map[string]any{
+					kml.NameProperty:         "point",
+					kml.DescriptionProperty:  "a point",
+					kml.StyleURLProperty:     "#style",
+					kml.AltitudeModeProperty: "absolute",
+					kml.ExtrudeProperty:      true,
+					"population":             42,
+				},
+			},
+			{
+				Geometry: *geometry.NewGeometry(mustNewGeomFromWKT(t, "MULTILINESTRING ((0 1, 2 3), (4 5, 6 7))")),
+			},
+		},
+	}
+
+	data := &strings.Builder{}
+	assert.NoError(t, xml.NewEncoder(data).Encode(document))
+	assert.Equal(t, ""+
+		`<kml xmlns="http://www.opengis.net/kml/2.2">`+
+		"<Document>"+
+		"<name>document</name>"+
+		`<Style id="style">`+
+		"<LineStyle><color>ff0000ff</color><width>2</width></LineStyle>"+
+		"<PolyStyle><fill>false</fill></PolyStyle>"+
+		"</Style>"+
+		`<Placemark id="1">`+
+		"<name>point</name>"+
+		"<description>a point</description>"+
+		"<styleUrl>#style</styleUrl>"+
+		`<ExtendedData><Data name="population"><value>42</value></Data></ExtendedData>`+
+		"<Point><extrude>1</extrude><altitudeMode>absolute</altitudeMode><coordinates>0,1,2</coordinates></Point>"+
+		"</Placemark>"+
+		"<Placemark>"+
+		"<MultiGeometry>"+
+		"<LineString><coordinates>0,1 2,3</coordinates></LineString>"+
+		"<LineString><coordinates>4,5 6,7</coordinates></LineString>"+
+		"</MultiGeometry>"+
+		"</Placemark>"+
+		"</Document>"+
+		"</kml>",
+		data.String(),
+	)
+
+	var actual kml.Document
+	assert.NoError(t, xml.Unmarshal([]byte(data.String()), &actual))
+	assert.Equal(t, "document", actual.Name)
+	assert.Equal(t, document.Styles, actual.Styles)
+	assert.Equal(t, 2, len(actual.Features))
+
+	assert.Equal(t, any("1"), actual.Features[0].ID)
+	assert.Equal(t, map[string]any{
+		kml.NameProperty:         "point",
+		kml.DescriptionProperty:  "a point",
+		kml.StyleURLProperty:     "#style",
+		kml.AltitudeModeProperty: "absolute",
+		kml.ExtrudeProperty:      true,
+		"population":             "42",
+	}, actual.Features[0].Properties)
+	assert.True(t, actual.Features[0].Geometry.Equals(document.Features[0].Geometry.Geom))
+
+	assert.Equal(t, nil, actual.Features[1].ID)
+	assert.Equal(t, map[string]any(nil), actual.Features[1].Properties)
+	assert.Equal(t, geos.TypeIDMultiLineString, actual.Features[1].Geometry.TypeID())
+	assert.True(t, actual.Features[1].Geometry.Equals(document.Features[1].Geometry.Geom))
+}
+
+func TestDocumentUnmarshalFolders(t *testing.T) {
+	var actual kml.Document
+	assert.NoError(t, xml.Unmarshal([]byte(""+
+		`<kml xmlns="http://www.opengis.net/kml/2.2">`+
+		"<Document>"+
+		"<name>document</name>"+
+		"<Folder>"+
+		"<name>folder</name>"+
+		"<Placemark>"+
+		"<ExtendedData>"+
+		`<SchemaData schemaUrl="#schema"><SimpleData name="key">value</SimpleData></SchemaData>`+
+		"</ExtendedData>"+
+		"</Placemark>"+
+		"</Folder>"+
+		"</Document>"+
+		"</kml>",
+	), &actual))
+	assert.Equal(t, "document", actual.Name)
+	assert.Equal(t, 1, len(actual.Features))
+	assert.Equal(t, map[string]any{"key": "value"}, actual.Features[0].Properties)
+	assert.Zero(t, actual.Features[0].Geometry.Geom)
+}
+
+func TestDocumentUnmarshalMultipleGeometries(t *testing.T) {
+	var actual kml.Document
+	assert.Error(t, xml.Unmarshal([]byte(""+
+		"<kml>"+
+		"<Placemark>"+
+		"<Point><coordinates>0,1</coordinates></Point>"+
+		"<LineString><coordinates>0,1 2,3</coordinates></LineString>"+
+		"</Placemark>"+
+		"</kml>",
+	), &actual))
+}
+
+func mustNewGeomFromWKT(t *testing.T, wkt string) *geos.Geom {
+	t.Helper()
+	g, err := geos.NewGeomFromWKT(wkt)
+	assert.NoError(t, err)
+	return g
+}
@@ -0,0 +1,109 @@
+package geos
+
+import "slices"
+
+// LineSequence returns a MultiLineString containing the LineStrings of g,
+// which must be a LineString or a MultiLineString, ordered and oriented so
+// that each LineString starts where the previous LineString ends. It returns
+// an error if the LineStrings cannot be sequenced into a single continuous
+// path, i.e. if they are not connected or if more than two of their endpoints
+// are shared by an odd number of LineStrings.
+func (g *Geom) LineSequence() (*Geom, error) {
+	var lines []*Geom
+	switch g.TypeID() {
+	case TypeIDLineString:
+		lines = []*Geom{g}
+	case TypeIDMultiLineString:
+		for i := range g.NumGeometries() {
+			lines = append(lines, g.Geometry(i))
+		}
+	default:
+		return nil, errUnsupportedTypeID
+	}
+	lines = slices.DeleteFunc(lines, (*Geom).IsEmpty)
+	if len(lines) == 0 {
+		return g.context.NewEmptyCollection(TypeIDMultiLineString), nil
+	}
+
+	type node [2]float64
+	type edge struct {
+		line     int
+		to       node
+		reversed bool
+	}
+
+	var nodes []node
+	adjacency := make(map[node][]edge)
+	addNode := func(n node) {
+		if _, ok := adjacency[n]; !ok {
+			nodes = append(nodes, n)
+			adjacency[n] = nil
+		}
+	}
+	for i, line := range lines {
+		coordSeq := line.CoordSeq()
+		start := node{coordSeq.X(0), coordSeq.Y(0)}
+		end := node{coordSeq.X(coordSeq.Size() - 1), coordSeq.Y(coordSeq.Size() - 1)}
+		addNode(start)
+		addNode(end)
+		adjacency[start] = append(adjacency[start], edge{line: i, to: end})
+		adjacency[end] = append(adjacency[end], edge{line: i, to: start, reversed: true})
+	}
+
+	start := lines[0].CoordSeq()
+	startNode := node{start.X(0), start.Y(0)}
+	var oddNodes []node
+	for _, n := range nodes {
+		if len(adjacency[n])%2 == 1 {
+			oddNodes = append(oddNodes, n)
+		}
+	}
+	switch len(oddNodes) {
+	case 0:
+	case 2:
+		startNode = oddNodes[0]
+	default:
+		return nil, errCannotSequence
+	}
+
+	// Find an Eulerian path using Hierholzer's algorithm.
+	type step struct {
+		node node
+		edge *edge
+	}
+	used := make([]bool, len(lines))
+	next := make(map[node]int, len(nodes))
+	stack := []step{{node: startNode}}
+	path := make([]*edge, 0, len(lines))
+	for len(stack) > 0 {
+		top := stack[len(stack)-1]
+		edges := adjacency[top.node]
+		for next[top.node] < len(edges) && used[edges[next[top.node]].line] {
+			next[top.node]++
+		}
+		if next[top.node] < len(edges) {
+			e := &edges[next[top.node]]
+			used[e.line] = true
+			stack = append(stack, step{node: e.to, edge: e})
+			continue
+		}
+		stack = stack[:len(stack)-1]
+		if top.edge != nil {
+			path = append(path, top.edge)
+		}
+	}
+	if len(path) != len(lines) {
+		return nil, errCannotSequence
+	}
+	slices.Reverse(path)
+
+	sequence := make([]*Geom, 0, len(path))
+	for _, e := range path {
+		if e.reversed {
+			sequence = append(sequence, lines[e.line].Reverse())
+		} else {
+			sequence = append(sequence, lines[e.line].Clone())
+		}
+	}
+	return g.context.NewCollection(TypeIDMultiLineString, sequence), nil
+}
@@ -0,0 +1,73 @@
+package geos_test
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+
+	"github.com/twpayne/go-geos"
+)
+
+func TestGeomLineSequence(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		wkt         string
+		expectedWKT string
+		expectedErr bool
+	}{
+		{
+			name:        "linestring",
+			wkt:         "LINESTRING (0 0, 1 0)",
+			expectedWKT: "MULTILINESTRING ((0 0, 1 0))",
+		},
+		{
+			name:        "empty",
+			wkt:         "MULTILINESTRING EMPTY",
+			expectedWKT: "MULTILINESTRING EMPTY",
+		},
+		{
+			name:        "path",
+			wkt:         "MULTILINESTRING ((1 0, 2 0), (0 0, 1 0), (3 0, 2 0))",
+			expectedWKT: "MULTILINESTRING ((0 0, 1 0), (1 0, 2 0), (2 0, 3 0))",
+		},
+		{
+			name:        "circuit",
+			wkt:         "MULTILINESTRING ((0 0, 1 0), (1 1, 0 0), (1 0, 1 1))",
+			expectedWKT: "MULTILINESTRING ((0 0, 1 0), (1 0, 1 1), (1 1, 0 0))",
+		},
+		{
+			name:        "fork",
+			wkt:         "MULTILINESTRING ((0 0, 1 0), (1 0, 2 0), (1 0, 1 1))",
+			expectedErr: true,
+		},
+		{
+			name:        "disconnected",
+			wkt:         "MULTILINESTRING ((0 0, 1 0), (5 5, 6 6))",
+			expectedErr: true,
+		},
+		{
+			name:        "disconnected_rings",
+			wkt:         "MULTILINESTRING ((0 0, 1 0, 1 1, 0 0), (5 5, 6 5, 6 6, 5 5))",
+			expectedErr: true,
+		},
+		{
+			name:        "polygon",
+			wkt:         "POLYGON ((0 0, 1 0, 1 1, 0 0))",
+			expectedErr: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			defer runtime.GC() // Exercise finalizers.
+			c := geos.NewContext()
+			g := mustNewGeomFromWKT(t, c, tc.wkt)
+			actual, err := g.LineSequence()
+			if tc.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedWKT, actual.ToWKT())
+		})
+	}
+}
@@ -0,0 +1,795 @@
+// Package mvt implements GEOS-backed Mapbox Vector Tile encoding and decoding.
+//
+// Feature geometries are in longitude/latitude and are projected into Web
+// Mercator tile coordinates on encoding and back again on decoding.
+package mvt
+
+import (
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/twpayne/go-geos"
+	"github.com/twpayne/go-geos/geojson"
+	"github.com/twpayne/go-geos/geometry"
+)
+
+// Defaults, as used by PostGIS's ST_AsMVTGeom.
+const (
+	DefaultBuffer = 256
+	DefaultExtent = 4096
+)
+
+// maxLatitude is the maximum latitude representable in Web Mercator.
+const maxLatitude = 85.05112877980659
+
+// Field numbers of the vector tile protocol buffer messages.
+const (
+	tileLayersField = 3
+
+	layerNameField     = 1
+	layerFeaturesField = 2
+	layerKeysField     = 3
+	layerValuesField   = 4
+	layerExtentField   = 5
+	layerVersionField  = 15
+
+	featureIDField       = 1
+	featureTagsField     = 2
+	featureTypeField     = 3
+	featureGeometryField = 4
+
+	valueStringField = 1
+	valueFloatField  = 2
+	valueDoubleField = 3
+	valueIntField    = 4
+	valueUintField   = 5
+	valueSintField   = 6
+	valueBoolField   = 7
+)
+
+// layerVersion is the version of the vector tile specification implemented.
+const layerVersion = 2
+
+// Vector tile geometry types.
+const (
+	geomTypeUnknown    = 0
+	geomTypePoint      = 1
+	geomTypeLineString = 2
+	geomTypePolygon    = 3
+)
+
+// Vector tile geometry commands.
+const (
+	commandMoveTo    = 1
+	commandLineTo    = 2
+	commandClosePath = 7
+)
+
+// A TileID identifies a tile.
+type TileID struct {
+	Z int
+	X int
+	Y int
+}
+
+// A Layer is a layer of features.
+type Layer struct {
+	Name     string
+	Extent   int
+	Features geojson.FeatureCollection
+}
+
+// An Encoder encodes layers as Mapbox Vector Tiles.
+type Encoder struct {
+	buffer int
+}
+
+// An EncoderOption sets an option on an Encoder.
+type EncoderOption func(*Encoder)
+
+// WithBuffer sets the size of the buffer around each tile, in tile
+// coordinates, within which geometries are retained.
+func WithBuffer(buffer int) EncoderOption {
+	return func(e *Encoder) {
+		e.buffer = buffer
+	}
+}
+
+// NewEncoder returns a new Encoder with the given options.
+func NewEncoder(options ...EncoderOption) *Encoder {
+	e := &Encoder{
+		buffer: DefaultBuffer,
+	}
+	for _, option := range options {
+		option(e)
+	}
+	return e
+}
+
+// Bounds returns t's bounds in longitude/latitude.
+func (t TileID) Bounds() *geos.Box2D {
+	minX, maxY := t.unproject(DefaultExtent)(0, 0)
+	maxX, minY := t.unproject(DefaultExtent)(DefaultExtent, DefaultExtent)
+	return geos.NewBox2D(minX, minY, maxX, maxY)
+}
+
+// project returns a function that projects longitude/latitude into t's tile
+// coordinates with the given extent.
+func (t TileID) project(extent int) func(float64, float64) (float64, float64) {
+	n := math.Exp2(float64(t.Z))
+	return func(lon, lat float64) (float64, float64) {
+		lat = max(-maxLatitude, min(lat, maxLatitude))
+		x := (lon+180)/360*n - float64(t.X)
+		y := (1-math.Asinh(math.Tan(lat*math.Pi/180))/math.Pi)/2*n - float64(t.Y)
+		return x * float64(extent), y * float64(extent)
+	}
+}
+
+// unproject returns a function that projects t's tile coordinates with the
+// given extent into longitude/latitude.
+func (t TileID) unproject(extent int) func(float64, float64) (float64, float64) {
+	n := math.Exp2(float64(t.Z))
+	return func(x, y float64) (float64, float64) {
+		lon := (x/float64(extent)+float64(t.X))/n*360 - 180
+		lat := math.Atan(math.Sinh(math.Pi*(1-2*(y/float64(extent)+float64(t.Y))/n))) * 180 / math.Pi
+		return lon, lat
+	}
+}
+
+// Encode returns the Mapbox Vector Tile encoding of layers in tile tileID.
+func (e *Encoder) Encode(tileID TileID, layers []*Layer) ([]byte, error) {
+	var data []byte
+	for _, layer := range layers {
+		layerData, err := e.encodeLayer(tileID, layer)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", layer.Name, err)
+		}
+		data = appendBytesField(data, tileLayersField, layerData)
+	}
+	return data, nil
+}
+
+// A value is a feature property value.
+type value struct {
+	field       int
+	stringValue string
+	floatValue  float32
+	doubleValue float64
+	intValue    int64
+	uintValue   uint64
+	boolValue   bool
+}
+
+// A layerEncoder accumulates the features, keys, and values of a layer.
+type layerEncoder struct {
+	features      [][]byte
+	keys          []string
+	keyIndex      map[string]int
+	values        []value
+	valueIndex    map[value]int
+	project       func(float64, float64) (float64, float64)
+	clipBox       *geos.Box2D
+	lonLatClipBox *geos.Box2D
+}
+
+func (e *Encoder) encodeLayer(tileID TileID, layer *Layer) ([]byte, error) {
+	extent := layer.Extent
+	if extent == 0 {
+		extent = DefaultExtent
+	}
+	buffer := float64(e.buffer)
+	unproject := tileID.unproject(extent)
+	minLon, maxLat := unproject(-buffer, -buffer)
+	maxLon, minLat := unproject(float64(extent)+buffer, float64(extent)+buffer)
+	le := &layerEncoder{
+		keyIndex:      make(map[string]int),
+		valueIndex:    make(map[value]int),
+		project:       tileID.project(extent),
+		clipBox:       geos.NewBox2D(-buffer, -buffer, float64(extent)+buffer, float64(extent)+buffer),
+		lonLatClipBox: geos.NewBox2D(minLon, minLat, maxLon, maxLat),
+	}
+	for _, feature := range layer.Features {
+		if err := le.encodeFeature(feature); err != nil {
+			return nil, err
+		}
+	}
+
+	var data []byte
+	data = appendStringField(data, layerNameField, layer.Name)
+	for _, feature := range le.features {
+		data = appendBytesField(data, layerFeaturesField, feature)
+	}
+	for _, key := range le.keys {
+		data = appendStringField(data, layerKeysField, key)
+	}
+	for _, value := range le.values {
+		data = appendBytesField(data, layerValuesField, value.appendMessage(nil))
+	}
+	data = appendVarintField(data, layerExtentField, uint64(extent))
+	data = appendVarintField(data, layerVersionField, layerVersion)
+	return data, nil
+}
+
+func (le *layerEncoder) encodeFeature(feature *geojson.Feature) error {
+	geom := le.tileGeom(feature.Geometry.Geom)
+	if geom == nil {
+		return nil
+	}
+
+	var tags []uint32
+	keys := make([]string, 0, len(feature.Properties))
+	for key := range feature.Properties {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	for _, key := range keys {
+		value, ok, err := newValue(feature.Properties[key])
+		switch {
+		case err != nil:
+			return fmt.Errorf("%s: %w", key, err)
+		case !ok:
+			continue
+		}
+		keyIndex, ok := le.keyIndex[key]
+		if !ok {
+			keyIndex = len(le.keys)
+			le.keys = append(le.keys, key)
+			le.keyIndex[key] = keyIndex
+		}
+		valueIndex, ok := le.valueIndex[value]
+		if !ok {
+			valueIndex = len(le.values)
+			le.values = append(le.values, value)
+			le.valueIndex[value] = valueIndex
+		}
+		tags = append(tags, uint32(keyIndex), uint32(valueIndex))
+	}
+
+	id, hasID := featureID(feature.ID)
+
+	// Vector tiles have no geometry collections, so each dimension of the
+	// geometry is encoded as a separate feature.
+	var points [][]float64
+	var lines, polygons []*geos.Geom
+	flatten(geom, &points, &lines, &polygons)
+	for _, part := range []struct {
+		geomType int
+		commands []uint32
+	}{
+		{geomTypePoint, encodePoints(points)},
+		{geomTypeLineString, encodeLines(lines)},
+		{geomTypePolygon, encodePolygons(polygons)},
+	} {
+		if len(part.commands) == 0 {
+			continue
+		}
+		var data []byte
+		if hasID {
+			data = appendVarintField(data, featureIDField, id)
+		}
+		data = appendPackedUint32sField(data, featureTagsField, tags)
+		data = appendVarintField(data, featureTypeField, uint64(part.geomType))
+		data = appendPackedUint32sField(data, featureGeometryField, part.commands)
+		le.features = append(le.features, data)
+	}
+	return nil
+}
+
+// tileGeom returns geom projected into tile coordinates, clipped to the
+// buffered tile, snapped to the integer grid, made valid, and with exterior
+// rings with positive area in tile coordinates. It returns nil if nothing
+// remains.
+func (le *layerEncoder) tileGeom(geom *geos.Geom) *geos.Geom {
+	if geom == nil || geom.IsEmpty() || !geom.Bounds().Intersects(le.lonLatClipBox) {
+		return nil
+	}
+	geom = transform(geom, le.project).
+		ClipByBox2D(le.clipBox).
+		SetPrecision(1, geos.PrecisionRuleValidOutput)
+	if geom.IsEmpty() {
+		return nil
+	}
+	if !geom.IsValid() {
+		geom = geom.MakeValid()
+	}
+	// Tile coordinates have Y pointing down, so counter-clockwise rings in
+	// GEOS's orientation have positive area in tile coordinates.
+	return geom.OrientPolygons(false)
+}
+
+// Decode decodes the layers of the Mapbox Vector Tile data in tile tileID.
+func Decode(tileID TileID, data []byte) ([]*Layer, error) {
+	var layers []*Layer
+	r := &protobufReader{data: data}
+	for {
+		field, ok, err := r.next()
+		switch {
+		case err != nil:
+			return nil, err
+		case !ok:
+			return layers, nil
+		case field.number != tileLayersField || field.wireType != wireTypeLen:
+			continue
+		}
+		layer, err := decodeLayer(tileID, field.bytes)
+		if err != nil {
+			return nil, err
+		}
+		layers = append(layers, layer)
+	}
+}
+
+func decodeLayer(tileID TileID, data []byte) (*Layer, error) {
+	layer := &Layer{
+		Extent: DefaultExtent,
+	}
+	var featuresData [][]byte
+	var keys []string
+	var values []any
+	r := &protobufReader{data: data}
+	for {
+		field, ok, err := r.next()
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			break
+		}
+		switch field.number {
+		case layerNameField:
+			layer.Name = string(field.bytes)
+		case layerFeaturesField:
+			featuresData = append(featuresData, field.bytes)
+		case layerKeysField:
+			keys = append(keys, string(field.bytes))
+		case layerValuesField:
+			value, err := decodeValue(field.bytes)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, value)
+		case layerExtentField:
+			layer.Extent = int(field.varint)
+		}
+	}
+	if layer.Extent <= 0 {
+		return nil, fmt.Errorf("%s: %d: invalid extent", layer.Name, layer.Extent)
+	}
+
+	unproject := tileID.unproject(layer.Extent)
+	for _, featureData := range featuresData {
+		feature, err := decodeFeature(featureData, keys, values, unproject)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", layer.Name, err)
+		}
+		if feature != nil {
+			layer.Features = append(layer.Features, feature)
+		}
+	}
+	return layer, nil
+}
+
+func decodeFeature(data []byte, keys []string, values []any, unproject func(float64, float64) (float64, float64)) (*geojson.Feature, error) {
+	feature := &geojson.Feature{}
+	geomType := geomTypeUnknown
+	var tags, commands []uint32
+	r := &protobufReader{data: data}
+	for {
+		field, ok, err := r.next()
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			break
+		}
+		switch field.number {
+		case featureIDField:
+			feature.ID = field.varint
+		case featureTagsField:
+			fieldTags, err := field.packedUint32s()
+			if err != nil {
+				return nil, err
+			}
+			tags = append(tags, fieldTags...)
+		case featureTypeField:
+			geomType = int(field.varint)
+		case featureGeometryField:
+			fieldCommands, err := field.packedUint32s()
+			if err != nil {
+				return nil, err
+			}
+			commands = append(commands, fieldCommands...)
+		}
+	}
+
+	if len(tags)%2 != 0 {
+		return nil, errInvalidProtobuf
+	}
+	if len(tags) > 0 {
+		feature.Properties = make(map[string]any, len(tags)/2)
+		for i := 0; i < len(tags); i += 2 {
+			keyIndex, valueIndex := int(tags[i]), int(tags[i+1])
+			if keyIndex >= len(keys) || valueIndex >= len(values) {
+				return nil, errInvalidProtobuf
+			}
+			feature.Properties[keys[keyIndex]] = values[valueIndex]
+		}
+	}
+
+	parts, err := decodeCommands(commands)
+	if err != nil {
+		return nil, err
+	}
+	geom := newGeom(geomType, parts)
+	if geom == nil {
+		return nil, nil
+	}
+	geom = transform(geom, unproject)
+	if geomType == geomTypePolygon {
+		// Unprojecting flips the Y axis, so restore RFC 7946 orientation.
+		geom.OrientPolygons(false)
+	}
+	feature.Geometry = *geometry.NewGeometry(geom)
+	return feature, nil
+}
+
+// decodeCommands decodes geometry commands into parts, where each MoveTo
+// starts a new part.
+func decodeCommands(commands []uint32) ([][][]float64, error) {
+	var parts [][][]float64
+	var x, y int64
+	for i := 0; i < len(commands); {
+		id, count := commands[i]&0x7, int(commands[i]>>3)
+		i++
+		switch id {
+		case commandMoveTo, commandLineTo:
+			if i+2*count > len(commands) || (id == commandLineTo && len(parts) == 0) {
+				return nil, errInvalidProtobuf
+			}
+			for range count {
+				x += unzigzag(commands[i])
+				y += unzigzag(commands[i+1])
+				i += 2
+				coord := []float64{float64(x), float64(y)}
+				if id == commandMoveTo {
+					parts = append(parts, [][]float64{coord})
+				} else {
+					parts[len(parts)-1] = append(parts[len(parts)-1], coord)
+				}
+			}
+		case commandClosePath:
+			if len(parts) == 0 {
+				return nil, errInvalidProtobuf
+			}
+			part := parts[len(parts)-1]
+			parts[len(parts)-1] = append(part, slices.Clone(part[0]))
+		default:
+			return nil, fmt.Errorf("%d: unknown command", id)
+		}
+	}
+	return parts, nil
+}
+
+// newGeom returns a new geometry in tile coordinates from parts.
+func newGeom(geomType int, parts [][][]float64) *geos.Geom {
+	switch geomType {
+	case geomTypePoint:
+		points := make([]*geos.Geom, 0, len(parts))
+		for _, part := range parts {
+			points = append(points, geos.NewPoint(part[0]))
+		}
+		switch len(points) {
+		case 0:
+			return nil
+		case 1:
+			return points[0]
+		default:
+			return geos.NewCollection(geos.TypeIDMultiPoint, points)
+		}
+	case geomTypeLineString:
+		lines := make([]*geos.Geom, 0, len(parts))
+		for _, part := range parts {
+			if len(part) >= 2 {
+				lines = append(lines, geos.NewLineString(part))
+			}
+		}
+		switch len(lines) {
+		case 0:
+			return nil
+		case 1:
+			return lines[0]
+		default:
+			return geos.NewCollection(geos.TypeIDMultiLineString, lines)
+		}
+	case geomTypePolygon:
+		var polygonss [][][][]float64
+		for _, part := range parts {
+			// Skip degenerate and unclosed rings from malformed tiles.
+			if len(part) < 4 || !slices.Equal(part[0], part[len(part)-1]) {
+				continue
+			}
+			switch area := signedArea(part); {
+			case area > 0:
+				polygonss = append(polygonss, [][][]float64{part})
+			case area < 0 && len(polygonss) > 0:
+				polygonss[len(polygonss)-1] = append(polygonss[len(polygonss)-1], part)
+			}
+		}
+		polygons := make([]*geos.Geom, 0, len(polygonss))
+		for _, polygon := range polygonss {
+			polygons = append(polygons, geos.NewPolygon(polygon))
+		}
+		switch len(polygons) {
+		case 0:
+			return nil
+		case 1:
+			return polygons[0]
+		default:
+			return geos.NewCollection(geos.TypeIDMultiPolygon, polygons)
+		}
+	default:
+		return nil
+	}
+}
+
+func decodeValue(data []byte) (any, error) {
+	var result any
+	r := &protobufReader{data: data}
+	for {
+		field, ok, err := r.next()
+		switch {
+		case err != nil:
+			return nil, err
+		case !ok:
+			return result, nil
+		}
+		switch field.number {
+		case valueStringField:
+			result = string(field.bytes)
+		case valueFloatField:
+			result = float64(math.Float32frombits(uint32(field.varint)))
+		case valueDoubleField:
+			result = math.Float64frombits(field.varint)
+		case valueIntField:
+			result = int64(field.varint)
+		case valueUintField:
+			result = field.varint
+		case valueSintField:
+			result = int64(field.varint>>1) ^ -int64(field.varint&1)
+		case valueBoolField:
+			result = field.varint != 0
+		}
+	}
+}
+
+func newValue(v any) (value, bool, error) {
+	switch v := v.(type) {
+	case nil:
+		return value{}, false, nil
+	case string:
+		return value{field: valueStringField, stringValue: v}, true, nil
+	case bool:
+		return value{field: valueBoolField, boolValue: v}, true, nil
+	case float32:
+		return value{field: valueFloatField, floatValue: v}, true, nil
+	case float64:
+		return value{field: valueDoubleField, doubleValue: v}, true, nil
+	case int:
+		return value{field: valueSintField, intValue: int64(v)}, true, nil
+	case int32:
+		return value{field: valueSintField, intValue: int64(v)}, true, nil
+	case int64:
+		return value{field: valueSintField, intValue: v}, true, nil
+	case uint:
+		return value{field: valueUintField, uintValue: uint64(v)}, true, nil
+	case uint32:
+		return value{field: valueUintField, uintValue: uint64(v)}, true, nil
+	case uint64:
+		return value{field: valueUintField, uintValue: v}, true, nil
+	default:
+		return value{}, false, fmt.Errorf("%T: unsupported property type", v)
+	}
+}
+
+func (v value) appendMessage(b []byte) []byte {
+	switch v.field {
+	case valueStringField:
+		return appendStringField(b, v.field, v.stringValue)
+	case valueFloatField:
+		return appendFloatField(b, v.field, v.floatValue)
+	case valueDoubleField:
+		return appendDoubleField(b, v.field, v.doubleValue)
+	case valueUintField:
+		return appendVarintField(b, v.field, v.uintValue)
+	case valueSintField:
+		return appendVarintField(b, v.field, uint64(zigzag(v.intValue)))
+	case valueBoolField:
+		var boolValue uint64
+		if v.boolValue {
+			boolValue = 1
+		}
+		return appendVarintField(b, v.field, boolValue)
+	default:
+		return b
+	}
+}
+
+// featureID returns the vector tile id of id, if it has one. Only
+// non-negative integers are valid ids.
+func featureID(id any) (uint64, bool) {
+	switch id := id.(type) {
+	case int:
+		return uint64(id), id >= 0
+	case int64:
+		return uint64(id), id >= 0
+	case uint64:
+		return id, true
+	case float64:
+		return uint64(id), id >= 0 && id == math.Trunc(id) && id < math.MaxUint64
+	default:
+		return 0, false
+	}
+}
+
+// flatten appends the points, lines, and polygons in geom.
+func flatten(geom *geos.Geom, points *[][]float64, lines, polygons *[]*geos.Geom) {
+	if geom.IsEmpty() {
+		return
+	}
+	switch geom.TypeID() {
+	case geos.TypeIDPoint:
+		*points = append(*points, geom.CoordSeq().ToCoords()...)
+	case geos.TypeIDLineString, geos.TypeIDLinearRing:
+		*lines = append(*lines, geom)
+	case geos.TypeIDPolygon:
+		*polygons = append(*polygons, geom)
+	default:
+		for i, n := 0, geom.NumGeometries(); i < n; i++ {
+			flatten(geom.Geometry(i), points, lines, polygons)
+		}
+	}
+}
+
+// A commandEncoder encodes geometry commands relative to a cursor.
+type commandEncoder struct {
+	commands []uint32
+	x, y     int64
+}
+
+func (e *commandEncoder) command(id, count int) {
+	e.commands = append(e.commands, uint32(id&0x7|count<<3))
+}
+
+func (e *commandEncoder) coords(coords [][2]int64) {
+	for _, coord := range coords {
+		e.commands = append(e.commands, zigzag(coord[0]-e.x), zigzag(coord[1]-e.y))
+		e.x, e.y = coord[0], coord[1]
+	}
+}
+
+func encodePoints(points [][]float64) []uint32 {
+	if len(points) == 0 {
+		return nil
+	}
+	e := &commandEncoder{}
+	e.command(commandMoveTo, len(points))
+	e.coords(intCoords(points))
+	return e.commands
+}
+
+func encodeLines(lines []*geos.Geom) []uint32 {
+	e := &commandEncoder{}
+	for _, line := range lines {
+		coords := dedupe(intCoords(line.CoordSeq().ToCoords()))
+		if len(coords) < 2 {
+			continue
+		}
+		e.command(commandMoveTo, 1)
+		e.coords(coords[:1])
+		e.command(commandLineTo, len(coords)-1)
+		e.coords(coords[1:])
+	}
+	return e.commands
+}
+
+func encodePolygons(polygons []*geos.Geom) []uint32 {
+	e := &commandEncoder{}
+	for _, polygon := range polygons {
+		rings := make([]*geos.Geom, 0, 1+polygon.NumInteriorRings())
+		rings = append(rings, polygon.ExteriorRing())
+		for i, n := 0, polygon.NumInteriorRings(); i < n; i++ {
+			rings = append(rings, polygon.InteriorRing(i))
+		}
+		for i, ring := range rings {
+			coords := dedupe(intCoords(ring.CoordSeq().ToCoords()))
+			if len(coords) > 1 && coords[0] == coords[len(coords)-1] {
+				coords = coords[:len(coords)-1]
+			}
+			if len(coords) < 3 {
+				if i == 0 {
+					break
+				}
+				continue
+			}
+			e.command(commandMoveTo, 1)
+			e.coords(coords[:1])
+			e.command(commandLineTo, len(coords)-1)
+			e.coords(coords[1:])
+			e.command(commandClosePath, 1)
+		}
+	}
+	return e.commands
+}
+
+// dedupe returns coords with consecutive duplicates removed.
+func dedupe(coords [][2]int64) [][2]int64 {
+	return slices.Compact(coords)
+}
+
+func intCoords(coords [][]float64) [][2]int64 {
+	result := make([][2]int64, 0, len(coords))
+	for _, coord := range coords {
+		result = append(result, [2]int64{int64(math.Round(coord[0])), int64(math.Round(coord[1]))})
+	}
+	return result
+}
+
+// signedArea returns the signed area of ring in tile coordinates. Exterior
+// rings have positive area.
+func signedArea(ring [][]float64) float64 {
+	var area float64
+	for i := range len(ring) - 1 {
+		area += ring[i][0]*ring[i+1][1] - ring[i+1][0]*ring[i][1]
+	}
+	return area / 2
+}
+
+// transform returns a new geometry with the coordinates of geom transformed
+// by f. Only X and Y coordinates are retained.
+func transform(geom *geos.Geom, f func(float64, float64) (float64, float64)) *geos.Geom {
+	transformCoords := func(g *geos.Geom) [][]float64 {
+		coords := g.CoordSeq().ToCoords()
+		for i, coord := range coords {
+			x, y := f(coord[0], coord[1])
+			coords[i] = []float64{x, y}
+		}
+		return coords
+	}
+	switch typeID := geom.TypeID(); typeID {
+	case geos.TypeIDPoint:
+		if geom.IsEmpty() {
+			return geos.NewEmptyPoint()
+		}
+		return geos.NewPoint(transformCoords(geom)[0])
+	case geos.TypeIDLineString, geos.TypeIDLinearRing:
+		if geom.IsEmpty() {
+			return geos.NewEmptyLineString()
+		}
+		return geos.NewLineString(transformCoords(geom))
+	case geos.TypeIDPolygon:
+		if geom.IsEmpty() {
+			return geos.NewEmptyPolygon()
+		}
+		coordss := make([][][]float64, 0, 1+geom.NumInteriorRings())
+		coordss = append(coordss, transformCoords(geom.ExteriorRing()))
+		for i, n := 0, geom.NumInteriorRings(); i < n; i++ {
+			coordss = append(coordss, transformCoords(geom.InteriorRing(i)))
+		}
+		return geos.NewPolygon(coordss)
+	default:
+		geoms := make([]*geos.Geom, 0, geom.NumGeometries())
+		for i, n := 0, geom.NumGeometries(); i < n; i++ {
+			geoms = append(geoms, transform(geom.Geometry(i), f))
+		}
+		return geos.NewCollection(typeID, geoms)
+	}
+}
+
+func unzigzag(u uint32) int64 {
+	return int64(u>>1) ^ -int64(u&1)
+}
+
+func zigzag(i int64) uint32 {
+	return uint32((i << 1) ^ (i >> 63))
+}
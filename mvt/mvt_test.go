@@ -0,0 +1,145 @@
+package mvt_test
+
+import (
+	"encoding/hex"
+	"math"
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+
+	"github.com/twpayne/go-geos"
+	"github.com/twpayne/go-geos/geojson"
+	"github.com/twpayne/go-geos/geometry"
+	"github.com/twpayne/go-geos/mvt"
+)
+
+func TestEncodePoint(t *testing.T) {
+	tileID := mvt.TileID{Z: 0, X: 0, Y: 0}
+	layers := []*mvt.Layer{
+		{
+			Name: "points",
+			Features: geojson.FeatureCollection{
+				{
+					Geometry: *geometry.NewGeometry(geos.NewPoint([]float64{0, 0})),
+				},
+			},
+		},
+	}
+	data, err := mvt.NewEncoder().Encode(tileID, layers)
+	assert.NoError(t, err)
+	assert.Equal(t, "1a180a06706f696e747312091801220509802080202880207802", hex.EncodeToString(data))
+
+	actual, err := mvt.Decode(tileID, data)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(actual))
+	assert.Equal(t, "points", actual[0].Name)
+	assert.Equal(t, mvt.DefaultExtent, actual[0].Extent)
+	assert.Equal(t, 1, len(actual[0].Features))
+	assert.Equal(t, "POINT (0 0)", actual[0].Features[0].Geometry.ToWKT())
+}
+
+func TestEncodeDecode(t *testing.T) {
+	tileID := mvt.TileID{Z: 1, X: 1, Y: 0}
+	polygon := mustNewGeomFromWKT(t, "POLYGON ((10 10, 20 10, 20 20, 10 20, 10 10), (12 12, 12 14, 14 14, 14 12, 12 12))")
+	layers := []*mvt.Layer{
+		{
+			Name: "features",
+			Features: geojson.FeatureCollection{
+				{
+					ID:       42,
+					Geometry: *geometry.NewGeometry(polygon),
+					Properties: map[string]any{
+						"b":    true,
+						"f":    1.5,
+						"n":    -3,
+						"name": "a",
+						"nil":  nil,
+						"u":    uint64(7),
+					},
+				},
+				{
+					Geometry: *geometry.NewGeometry(mustNewGeomFromWKT(t, "LINESTRING (-170 10, 170 10)")),
+				},
+				{
+					Geometry: *geometry.NewGeometry(mustNewGeomFromWKT(t, "POINT (-90 45)")),
+				},
+			},
+		},
+	}
+	data, err := mvt.NewEncoder().Encode(tileID, layers)
+	assert.NoError(t, err)
+
+	actual, err := mvt.Decode(tileID, data)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(actual))
+	assert.Equal(t, 2, len(actual[0].Features))
+
+	polygonFeature := actual[0].Features[0]
+	assert.Equal(t, any(uint64(42)), polygonFeature.ID)
+	assert.Equal(t, map[string]any{
+		"b":    true,
+		"f":    1.5,
+		"n":    int64(-3),
+		"name": "a",
+		"u":    uint64(7),
+	}, polygonFeature.Properties)
+	assert.Equal(t, geos.TypeIDPolygon, polygonFeature.Geometry.TypeID())
+	assert.True(t, polygonFeature.Geometry.IsValid())
+	assert.True(t, polygonFeature.Geometry.ExteriorRing().CoordSeq().IsCCW())
+	assert.True(t, polygonFeature.Geometry.HausdorffDistance(polygon) < 0.1)
+
+	// The line is clipped to the tile plus its buffer, which is 256/4096ths of
+	// the tile's width of 180 degrees.
+	lineFeature := actual[0].Features[1]
+	assert.Equal(t, nil, lineFeature.ID)
+	assert.Equal(t, geos.TypeIDLineString, lineFeature.Geometry.TypeID())
+	bounds := lineFeature.Geometry.Bounds()
+	assert.True(t, math.Abs(bounds.MinX-(-11.25)) < 0.1)
+	assert.True(t, math.Abs(bounds.MaxX-170) < 0.1)
+}
+
+func TestTileIDBounds(t *testing.T) {
+	bounds := mvt.TileID{Z: 1, X: 1, Y: 0}.Bounds()
+	assert.Equal(t, 0.0, bounds.MinX)
+	assert.Equal(t, 180.0, bounds.MaxX)
+	assert.True(t, math.Abs(bounds.MinY) < 1e-9)
+	assert.True(t, math.Abs(bounds.MaxY-85.0511287798066) < 1e-9)
+}
+
+func TestDecodeInvalid(t *testing.T) {
+	_, err := mvt.Decode(mvt.TileID{}, []byte{0x1a, 0x10, 0x0a})
+	assert.Error(t, err)
+}
+
+func TestDecodeInvalidExtent(t *testing.T) {
+	data, err := hex.DecodeString("1a050a01612800")
+	assert.NoError(t, err)
+	_, err = mvt.Decode(mvt.TileID{}, data)
+	assert.Error(t, err)
+}
+
+func TestDecodeUnclosedRing(t *testing.T) {
+	// A polygon feature with a MoveTo and three LineTos but no ClosePath.
+	data, err := hex.DecodeString("1a160a0161120e1803220a0900001a040000040300288020")
+	assert.NoError(t, err)
+	actual, err := mvt.Decode(mvt.TileID{}, data)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(actual))
+	assert.Equal(t, 0, len(actual[0].Features))
+
+	// The same feature with a ClosePath.
+	data, err = hex.DecodeString("1a170a0161120f1803220b0900001a0400000403000f288020")
+	assert.NoError(t, err)
+	actual, err = mvt.Decode(mvt.TileID{}, data)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(actual))
+	assert.Equal(t, 1, len(actual[0].Features))
+	assert.Equal(t, geos.TypeIDPolygon, actual[0].Features[0].Geometry.TypeID())
+}
+
+func mustNewGeomFromWKT(t *testing.T, wkt string) *geos.Geom {
+	t.Helper()
+	g, err := geos.NewGeomFromWKT(wkt)
+	assert.NoError(t, err)
+	return g
+}
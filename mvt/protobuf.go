@@ -0,0 +1,137 @@
+package mvt
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+)
+
+// Protocol buffer wire types.
+const (
+	wireTypeVarint = 0
+	wireTypeI64    = 1
+	wireTypeLen    = 2
+	wireTypeI32    = 5
+)
+
+var errInvalidProtobuf = errors.New("invalid protobuf")
+
+func appendTag(b []byte, field, wireType int) []byte {
+	return binary.AppendUvarint(b, uint64(field<<3|wireType))
+}
+
+func appendVarintField(b []byte, field int, value uint64) []byte {
+	b = appendTag(b, field, wireTypeVarint)
+	return binary.AppendUvarint(b, value)
+}
+
+func appendBytesField(b []byte, field int, value []byte) []byte {
+	b = appendTag(b, field, wireTypeLen)
+	b = binary.AppendUvarint(b, uint64(len(value)))
+	return append(b, value...)
+}
+
+func appendStringField(b []byte, field int, value string) []byte {
+	b = appendTag(b, field, wireTypeLen)
+	b = binary.AppendUvarint(b, uint64(len(value)))
+	return append(b, value...)
+}
+
+func appendFloatField(b []byte, field int, value float32) []byte {
+	b = appendTag(b, field, wireTypeI32)
+	return binary.LittleEndian.AppendUint32(b, math.Float32bits(value))
+}
+
+func appendDoubleField(b []byte, field int, value float64) []byte {
+	b = appendTag(b, field, wireTypeI64)
+	return binary.LittleEndian.AppendUint64(b, math.Float64bits(value))
+}
+
+func appendPackedUint32sField(b []byte, field int, values []uint32) []byte {
+	if len(values) == 0 {
+		return b
+	}
+	var packed []byte
+	for _, value := range values {
+		packed = binary.AppendUvarint(packed, uint64(value))
+	}
+	return appendBytesField(b, field, packed)
+}
+
+// A protobufField is a single field read from a protocol buffer message.
+type protobufField struct {
+	number   int
+	wireType int
+	varint   uint64
+	bytes    []byte
+}
+
+// A protobufReader reads fields from a protocol buffer message.
+type protobufReader struct {
+	data []byte
+}
+
+// next returns the next field. It returns false when there are no more
+// fields.
+func (r *protobufReader) next() (protobufField, bool, error) {
+	if len(r.data) == 0 {
+		return protobufField{}, false, nil
+	}
+	tag, n := binary.Uvarint(r.data)
+	if n <= 0 {
+		return protobufField{}, false, errInvalidProtobuf
+	}
+	r.data = r.data[n:]
+	field := protobufField{
+		number:   int(tag >> 3),
+		wireType: int(tag & 0x7),
+	}
+	switch field.wireType {
+	case wireTypeVarint:
+		field.varint, n = binary.Uvarint(r.data)
+		if n <= 0 {
+			return protobufField{}, false, errInvalidProtobuf
+		}
+		r.data = r.data[n:]
+	case wireTypeI64:
+		if len(r.data) < 8 {
+			return protobufField{}, false, errInvalidProtobuf
+		}
+		field.varint = binary.LittleEndian.Uint64(r.data)
+		r.data = r.data[8:]
+	case wireTypeLen:
+		length, n := binary.Uvarint(r.data)
+		if n <= 0 || uint64(len(r.data)-n) < length {
+			return protobufField{}, false, errInvalidProtobuf
+		}
+		field.bytes = r.data[n : n+int(length)]
+		r.data = r.data[n+int(length):]
+	case wireTypeI32:
+		if len(r.data) < 4 {
+			return protobufField{}, false, errInvalidProtobuf
+		}
+		field.varint = uint64(binary.LittleEndian.Uint32(r.data))
+		r.data = r.data[4:]
+	default:
+		return protobufField{}, false, errInvalidProtobuf
+	}
+	return field, true, nil
+}
+
+// packedUint32s returns the values of a packed repeated uint32 field.
+func (f protobufField) packedUint32s() ([]uint32, error) {
+	if f.wireType == wireTypeVarint {
+		return []uint32{uint32(f.varint)}, nil
+	}
+	var values []uint32
+	data := f.bytes
+	for len(data) > 0 {
+		value, n := binary.Uvarint(data)
+		if n <= 0 {
+			return nil, errInvalidProtobuf
+		}
+		values = append(values, uint32(value))
+		data = data[n:]
+	}
+	return values, nil
+}
@@ -1,9 +1,13 @@
 package geos
 
+// #include <stdlib.h>
 // #include "go-geos.h"
 import "C"
 
-import "runtime"
+import (
+	"runtime"
+	"unsafe"
+)
 
 // A PrepGeom is a prepared geometry.
 type PrepGeom struct {
@@ -147,6 +151,21 @@ func (pg *PrepGeom) Disjoint(g *Geom) bool {
 	}
 }
 
+// Distance returns the distance between pg and g.
+func (pg *PrepGeom) Distance(g *Geom) float64 {
+	pg.owner.context.mutex.Lock()
+	defer pg.owner.context.mutex.Unlock()
+	if g.context != pg.owner.context {
+		g.context.mutex.Lock()
+		defer g.context.mutex.Unlock()
+	}
+	var distance float64
+	if C.GEOSPreparedDistance_r(pg.owner.context.cHandle, pg.cPrepGeom, g.cGeom, (*C.double)(&distance)) == 0 {
+		panic(pg.owner.context.err)
+	}
+	return distance
+}
+
 // DistanceWithin returns if pg is within dist g.
 func (pg *PrepGeom) DistanceWithin(g *Geom, dist float64) bool {
 	pg.owner.context.mutex.Lock()
@@ -197,15 +216,21 @@ func (pg *PrepGeom) IntersectsXY(x, y float64) bool {
 	}
 }
 
-// NearestPoints returns if pg overlaps g.
-func (pg *PrepGeom) NearestPoints(g *Geom) *CoordSeq {
+// NearestPoints returns the nearest coordinates of pg and g. If the nearest
+// coordinates do not exist (e.g., when either geom is empty), it returns nil.
+func (pg *PrepGeom) NearestPoints(g *Geom) [][]float64 {
 	pg.owner.context.mutex.Lock()
 	defer pg.owner.context.mutex.Unlock()
 	if g.context != pg.owner.context {
 		g.context.mutex.Lock()
 		defer g.context.mutex.Unlock()
 	}
-	return pg.owner.context.newNonNilCoordSeq(C.GEOSPreparedNearestPoints_r(pg.owner.context.cHandle, pg.cPrepGeom, g.cGeom))
+	cCoordSeq := C.GEOSPreparedNearestPoints_r(pg.owner.context.cHandle, pg.cPrepGeom, g.cGeom)
+	if cCoordSeq == nil {
+		return nil
+	}
+	defer C.GEOSCoordSeq_destroy_r(pg.owner.context.cHandle, cCoordSeq)
+	return pg.owner.context.newCoordsFromGEOSCoordSeq(cCoordSeq)
 }
 
 // Overlaps returns if pg overlaps g.
@@ -226,6 +251,43 @@ func (pg *PrepGeom) Overlaps(g *Geom) bool {
 	}
 }
 
+// Relate returns the DE-9IM intersection matrix for pg and g.
+func (pg *PrepGeom) Relate(g *Geom) IntersectionMatrix {
+	pg.owner.context.mutex.Lock()
+	defer pg.owner.context.mutex.Unlock()
+	if g.context != pg.owner.context {
+		g.context.mutex.Lock()
+		defer g.context.mutex.Unlock()
+	}
+	relateCStr := C.c_GEOSPreparedRelate_r(pg.owner.context.cHandle, pg.cPrepGeom, pg.owner.cGeom, g.cGeom)
+	if relateCStr == nil {
+		panic(pg.owner.context.err)
+	}
+	defer C.GEOSFree_r(pg.owner.context.cHandle, unsafe.Pointer(relateCStr))
+	return mustParseIntersectionMatrix(C.GoString(relateCStr))
+}
+
+// RelatePattern returns if the DE-9IM intersection matrix for pg and g
+// matches pat.
+func (pg *PrepGeom) RelatePattern(g *Geom, pat string) bool {
+	patCStr := C.CString(pat)
+	defer C.free(unsafe.Pointer(patCStr))
+	pg.owner.context.mutex.Lock()
+	defer pg.owner.context.mutex.Unlock()
+	if g.context != pg.owner.context {
+		g.context.mutex.Lock()
+		defer g.context.mutex.Unlock()
+	}
+	switch C.c_GEOSPreparedRelatePattern_r(pg.owner.context.cHandle, pg.cPrepGeom, pg.owner.cGeom, g.cGeom, patCStr) {
+	case 0:
+		return false
+	case 1:
+		return true
+	default:
+		panic(pg.owner.context.err)
+	}
+}
+
 // Touches returns if pg contains g.
 func (pg *PrepGeom) Touches(g *Geom) bool {
 	pg.owner.context.mutex.Lock()
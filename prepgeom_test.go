@@ -22,12 +22,16 @@ func TestPrepGeom(t *testing.T) {
 	assert.True(t, unitSquare.Covers(middleSquare))
 	assert.False(t, unitSquare.Crosses(middleSquare))
 	assert.False(t, unitSquare.Disjoint(middleSquare))
+	assert.Equal(t, 0., unitSquare.Distance(middleSquare))
+	assert.Equal(t, 0.5, unitSquare.Distance(mustNewGeomFromWKT(t, c, "POINT (1.5 0.5)")))
 	assert.False(t, unitSquare.DistanceWithin(mustNewGeomFromWKT(t, c, "POINT (1.5 0.5)"), 0.1))
 	assert.True(t, unitSquare.Intersects(middleSquare))
 	assert.True(t, unitSquare.IntersectsXY(0.5, 0.5))
 	assert.False(t, unitSquare.IntersectsXY(2, 2))
-	assert.Equal(t, [][]float64{{1, 1}, {2, 2}}, unitSquare.NearestPoints(mustNewGeomFromWKT(t, c, "POINT (2 2)")).ToCoords())
+	assert.Equal(t, [][]float64{{1, 1}, {2, 2}}, unitSquare.NearestPoints(mustNewGeomFromWKT(t, c, "POINT (2 2)")))
 	assert.False(t, unitSquare.Overlaps(middleSquare))
+	assert.True(t, unitSquare.RelatePattern(middleSquare, "T*****FF*"))
+	assert.False(t, unitSquare.RelatePattern(middleSquare, "FF*FF****"))
 	assert.False(t, unitSquare.Touches(middleSquare))
 	assert.False(t, unitSquare.Within(middleSquare))
 }
@@ -0,0 +1,114 @@
+package geos
+
+// #include "go-geos.h"
+import "C"
+
+import "unsafe"
+
+// A ValidFlag is a flag for IsValidDetail.
+type ValidFlag int
+
+// Valid flags.
+const (
+	ValidFlagNone                             ValidFlag = 0
+	ValidFlagAllowSelfTouchingRingFormingHole ValidFlag = C.GEOSVALID_ALLOW_SELFTOUCHING_RING_FORMING_HOLE
+)
+
+// A ValidityReason is the reason that a geometry is invalid.
+type ValidityReason int
+
+// Validity reasons, in the same order as GEOS's
+// geos::operation::valid::TopologyValidationError.
+const (
+	ValidityReasonError ValidityReason = iota
+	ValidityReasonRepeatedPoint
+	ValidityReasonHoleOutsideShell
+	ValidityReasonNestedHoles
+	ValidityReasonDisconnectedInterior
+	ValidityReasonSelfIntersection
+	ValidityReasonRingSelfIntersection
+	ValidityReasonNestedShells
+	ValidityReasonDuplicateRings
+	ValidityReasonTooFewPoints
+	ValidityReasonInvalidCoordinate
+	ValidityReasonRingNotClosed
+	ValidityReasonValid
+)
+
+// validityReasonMessages are the messages that GEOS uses for each
+// ValidityReason.
+var validityReasonMessages = []string{
+	ValidityReasonError:                "Topology Validation Error",
+	ValidityReasonRepeatedPoint:        "Repeated Point",
+	ValidityReasonHoleOutsideShell:     "Hole lies outside shell",
+	ValidityReasonNestedHoles:          "Holes are nested",
+	ValidityReasonDisconnectedInterior: "Interior is disconnected",
+	ValidityReasonSelfIntersection:     "Self-intersection",
+	ValidityReasonRingSelfIntersection: "Ring Self-intersection",
+	ValidityReasonNestedShells:         "Nested shells",
+	ValidityReasonDuplicateRings:       "Duplicate Rings",
+	ValidityReasonTooFewPoints:         "Too few points in geometry component",
+	ValidityReasonInvalidCoordinate:    "Invalid Coordinate",
+	ValidityReasonRingNotClosed:        "Ring is not closed",
+	ValidityReasonValid:                "Valid Geometry",
+}
+
+// A ValidDetail describes the validity of a geometry.
+type ValidDetail struct {
+	// Valid is true if the geometry is valid.
+	Valid bool
+	// Reason is the reason that the geometry is invalid, or
+	// ValidityReasonValid if it is valid.
+	Reason ValidityReason
+	// Message is the reason as reported by GEOS.
+	Message string
+	// Location is the point at which the geometry is invalid, or nil if it is
+	// valid.
+	Location *Geom
+}
+
+// IsValidDetail returns details of g's validity.
+func (g *Geom) IsValidDetail(flags ValidFlag) *ValidDetail {
+	g.context.mutex.Lock()
+	defer g.context.mutex.Unlock()
+	var (
+		cReason   *C.char
+		cLocation *C.struct_GEOSGeom_t
+	)
+	switch C.GEOSisValidDetail_r(g.context.cHandle, g.cGeom, C.int(flags), &cReason, &cLocation) {
+	case 0:
+		defer C.GEOSFree_r(g.context.cHandle, unsafe.Pointer(cReason))
+		message := C.GoString(cReason)
+		return &ValidDetail{
+			Reason:   parseValidityReason(message),
+			Message:  message,
+			Location: g.context.newGeom(cLocation, nil),
+		}
+	case 1:
+		return &ValidDetail{
+			Valid:   true,
+			Reason:  ValidityReasonValid,
+			Message: validityReasonMessages[ValidityReasonValid],
+		}
+	default:
+		panic(g.context.err)
+	}
+}
+
+// String returns r as the message that GEOS uses.
+func (r ValidityReason) String() string {
+	if r < 0 || int(r) >= len(validityReasonMessages) {
+		return validityReasonMessages[ValidityReasonError]
+	}
+	return validityReasonMessages[r]
+}
+
+// parseValidityReason returns the ValidityReason corresponding to message.
+func parseValidityReason(message string) ValidityReason {
+	for reason, reasonMessage := range validityReasonMessages {
+		if message == reasonMessage {
+			return ValidityReason(reason)
+		}
+	}
+	return ValidityReasonError
+}
@@ -0,0 +1,75 @@
+package geos_test
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+
+	"github.com/twpayne/go-geos"
+)
+
+func TestGeomIsValidDetail(t *testing.T) {
+	for _, tc := range []struct {
+		name                string
+		wkt                 string
+		flags               geos.ValidFlag
+		expectedValid       bool
+		expectedReason      geos.ValidityReason
+		expectedLocationWKT string
+	}{
+		{
+			name:           "valid",
+			wkt:            "POLYGON ((0 0, 1 0, 1 1, 0 1, 0 0))",
+			expectedValid:  true,
+			expectedReason: geos.ValidityReasonValid,
+		},
+		{
+			name:                "self_intersection",
+			wkt:                 "POLYGON ((0 0, 1 1, 1 0, 0 1, 0 0))",
+			expectedReason:      geos.ValidityReasonSelfIntersection,
+			expectedLocationWKT: "POINT (0.5 0.5)",
+		},
+		{
+			name:                "hole_outside_shell",
+			wkt:                 "POLYGON ((0 0, 1 0, 1 1, 0 1, 0 0), (2 2, 3 2, 3 3, 2 3, 2 2))",
+			expectedReason:      geos.ValidityReasonHoleOutsideShell,
+			expectedLocationWKT: "POINT (2 2)",
+		},
+		{
+			name:                "too_few_points",
+			wkt:                 "LINESTRING (0 0, 0 0)",
+			expectedReason:      geos.ValidityReasonTooFewPoints,
+			expectedLocationWKT: "POINT (0 0)",
+		},
+		{
+			name:                "self_touching_ring_forming_hole",
+			wkt:                 "POLYGON ((0 0, 4 0, 4 4, 2 4, 3 2, 1 2, 2 4, 0 4, 0 0))",
+			expectedReason:      geos.ValidityReasonRingSelfIntersection,
+			expectedLocationWKT: "POINT (2 4)",
+		},
+		{
+			name:           "self_touching_ring_forming_hole_allowed",
+			wkt:            "POLYGON ((0 0, 4 0, 4 4, 2 4, 3 2, 1 2, 2 4, 0 4, 0 0))",
+			flags:          geos.ValidFlagAllowSelfTouchingRingFormingHole,
+			expectedValid:  true,
+			expectedReason: geos.ValidityReasonValid,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			defer runtime.GC() // Exercise finalizers.
+			c := geos.NewContext()
+			g, err := c.NewGeomFromWKT(tc.wkt)
+			assert.NoError(t, err)
+			validDetail := g.IsValidDetail(tc.flags)
+			assert.Equal(t, tc.expectedValid, validDetail.Valid)
+			assert.Equal(t, tc.expectedReason, validDetail.Reason)
+			assert.Equal(t, tc.expectedReason.String(), validDetail.Message)
+			if tc.expectedLocationWKT == "" {
+				assert.Zero(t, validDetail.Location)
+			} else {
+				assert.Equal(t, tc.expectedLocationWKT, validDetail.Location.ToWKT())
+			}
+		})
+	}
+}
@@ -0,0 +1,170 @@
+package geos
+
+import "math"
+
+// VariableBuffer returns the buffer of g, which must be a LineString, with a
+// width that varies linearly with the distance along g from startWidth at
+// its first point to endWidth at its last point, using GEOS's default buffer
+// parameters. See VariableBufferWithWidths.
+func (g *Geom) VariableBuffer(startWidth, endWidth float64) *Geom {
+	coords := g.variableBufferCoords()
+	widths := make([]float64, len(coords))
+	length := g.Length()
+	distance := 0.0
+	for i := range coords {
+		if i > 0 {
+			distance += math.Hypot(coords[i][0]-coords[i-1][0], coords[i][1]-coords[i-1][1])
+		}
+		if length == 0 {
+			widths[i] = startWidth
+		} else {
+			widths[i] = startWidth + (endWidth-startWidth)*distance/length
+		}
+	}
+	return g.variableBuffer(nil, coords, widths)
+}
+
+// VariableBufferWithWidths returns the buffer of g, which must be a LineString,
+// with the width at each vertex given by widths. The width of each segment
+// varies linearly between the widths at its vertices. The end caps and joins
+// are formed with the end cap style, join style, mitre limit, and quadrant
+// segments of bufParams, or GEOS's default buffer parameters if bufParams is
+// nil, using the width at the end or join vertex. Whether bufParams is single
+// sided is ignored. Negative widths are treated as zero, so if all widths are
+// zero or negative then the result is an empty Polygon.
+func (g *Geom) VariableBufferWithWidths(bufParams *BufParams, widths []float64) *Geom {
+	coords := g.variableBufferCoords()
+	if len(widths) != len(coords) {
+		panic(errLengthMismatch)
+	}
+	return g.variableBuffer(bufParams, coords, widths)
+}
+
+func (g *Geom) variableBuffer(bufParams *BufParams, coords [][]float64, widths []float64) *Geom {
+	coords, widths = variableBufferVertices(coords, widths)
+	if len(coords) == 0 {
+		return g.context.NewEmptyPolygon()
+	}
+	if bufParams == nil {
+		bufParams = g.context.NewBufParams()
+	}
+	capBufParams := bufParams.Clone().SetSingleSided(false)
+	if len(coords) == 1 {
+		if widths[0] == 0 {
+			return g.context.NewEmptyPolygon()
+		}
+		return g.context.NewPoint(coords[0]).BufferWithParams(capBufParams, widths[0])
+	}
+	joinBufParams := capBufParams.Clone().SetEndCapStyle(BufCapStyleFlat)
+	mitreLimit := max(bufParams.MitreLimit(), 1)
+
+	dirs := make([][2]float64, len(coords)-1)
+	for i := range dirs {
+		dx, dy := coords[i+1][0]-coords[i][0], coords[i+1][1]-coords[i][1]
+		length := math.Hypot(dx, dy)
+		dirs[i] = [2]float64{dx / length, dy / length}
+	}
+
+	pieces := make([]*Geom, 0, 2*len(coords))
+	for i, dir := range dirs {
+		if widths[i] == 0 && widths[i+1] == 0 {
+			continue
+		}
+		x0, y0, w0 := coords[i][0], coords[i][1], widths[i]
+		x1, y1, w1 := coords[i+1][0], coords[i+1][1], widths[i+1]
+		nx, ny := -dir[1], dir[0]
+		pieces = append(pieces, g.context.NewPolygon([][][]float64{{
+			{x0 + w0*nx, y0 + w0*ny},
+			{x1 + w1*nx, y1 + w1*ny},
+			{x1 - w1*nx, y1 - w1*ny},
+			{x0 - w0*nx, y0 - w0*ny},
+			{x0 + w0*nx, y0 + w0*ny},
+		}}))
+	}
+
+	// Form each end cap and join by buffering a short line around its vertex
+	// and keeping only the part beyond the adjacent segments.
+	last := len(coords) - 1
+	for i, coord := range coords {
+		width := widths[i]
+		if width == 0 {
+			continue
+		}
+		x, y := coord[0], coord[1]
+		var piece *Geom
+		switch i {
+		case 0:
+			dir := dirs[0]
+			line := [][]float64{{x, y}, {x + width*dir[0], y + width*dir[1]}}
+			piece = g.variableBufferClip(capBufParams, line, x, y, width, mitreLimit, [2]float64{-dir[0], -dir[1]})
+		case last:
+			dir := dirs[last-1]
+			line := [][]float64{{x - width*dir[0], y - width*dir[1]}, {x, y}}
+			piece = g.variableBufferClip(capBufParams, line, x, y, width, mitreLimit, dir)
+		default:
+			dirIn, dirOut := dirs[i-1], dirs[i]
+			line := [][]float64{
+				{x - width*dirIn[0], y - width*dirIn[1]},
+				{x, y},
+				{x + width*dirOut[0], y + width*dirOut[1]},
+			}
+			piece = g.variableBufferClip(joinBufParams, line, x, y, width, mitreLimit, dirIn, [2]float64{-dirOut[0], -dirOut[1]})
+		}
+		if piece.IsEmpty() || piece.Area() == 0 {
+			continue
+		}
+		pieces = append(pieces, piece)
+	}
+
+	if len(pieces) == 0 {
+		return g.context.NewEmptyPolygon()
+	}
+	return g.context.NewCollection(TypeIDGeometryCollection, pieces).UnaryUnion()
+}
+
+// variableBufferClip returns the part of the buffer of line with bufParams and
+// width that lies ahead of (x, y) in each of dirs.
+func (g *Geom) variableBufferClip(bufParams *BufParams, line [][]float64, x, y, width, mitreLimit float64, dirs ...[2]float64) *Geom {
+	piece := g.context.NewLineString(line).BufferWithParams(bufParams, width)
+	r := 2 * width * (1 + mitreLimit)
+	for _, dir := range dirs {
+		nx, ny := -dir[1], dir[0]
+		halfPlane := g.context.NewPolygon([][][]float64{{
+			{x - r*nx, y - r*ny},
+			{x + r*nx, y + r*ny},
+			{x + r*nx + r*dir[0], y + r*ny + r*dir[1]},
+			{x - r*nx + r*dir[0], y - r*ny + r*dir[1]},
+			{x - r*nx, y - r*ny},
+		}})
+		piece = piece.Intersection(halfPlane)
+	}
+	return piece
+}
+
+func (g *Geom) variableBufferCoords() [][]float64 {
+	if g.TypeID() != TypeIDLineString {
+		panic(errUnsupportedTypeID)
+	}
+	if g.IsEmpty() {
+		return nil
+	}
+	return g.CoordSeq().ToCoords()
+}
+
+// variableBufferVertices returns coords with consecutive duplicate points
+// removed, keeping the largest of their widths, and with negative widths
+// replaced by zero.
+func variableBufferVertices(coords [][]float64, widths []float64) ([][]float64, []float64) {
+	resultCoords := make([][]float64, 0, len(coords))
+	resultWidths := make([]float64, 0, len(widths))
+	for i, coord := range coords {
+		width := max(widths[i], 0)
+		if n := len(resultCoords); n > 0 && resultCoords[n-1][0] == coord[0] && resultCoords[n-1][1] == coord[1] {
+			resultWidths[n-1] = max(resultWidths[n-1], width)
+			continue
+		}
+		resultCoords = append(resultCoords, coord[:2])
+		resultWidths = append(resultWidths, width)
+	}
+	return resultCoords, resultWidths
+}
@@ -0,0 +1,82 @@
+package geos_test
+
+import (
+	"math"
+	"runtime"
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+
+	"github.com/twpayne/go-geos"
+)
+
+func TestGeomVariableBuffer(t *testing.T) {
+	defer runtime.GC() // Exercise finalizers.
+	c := geos.NewContext()
+	g := mustNewGeomFromWKT(t, c, "LINESTRING (0 0, 10 0)")
+
+	constant := g.VariableBuffer(1, 1)
+	assert.True(t, constant.IsValid())
+	assert.True(t, math.Abs(constant.Area()-g.Buffer(1, geos.DefaultBufQuadrantSegments).Area()) < 1e-9)
+
+	tapered := g.VariableBuffer(1, 2)
+	assert.True(t, tapered.IsValid())
+	assert.Equal(t, geos.TypeIDPolygon, tapered.TypeID())
+	assert.True(t, tapered.Contains(mustNewGeomFromWKT(t, c, "POINT (11.5 0)")))
+	assert.False(t, tapered.Contains(mustNewGeomFromWKT(t, c, "POINT (-1.5 0)")))
+	bounds := tapered.Bounds()
+	assert.True(t, math.Abs(bounds.MinX+1) < 1e-9)
+	assert.True(t, math.Abs(bounds.MaxX-12) < 1e-9)
+
+	assert.True(t, c.NewEmptyLineString().VariableBuffer(1, 2).IsEmpty())
+	assert.Panics(t, func() { mustNewGeomFromWKT(t, c, "POINT (0 0)").VariableBuffer(1, 2) })
+}
+
+func TestGeomVariableBufferWithWidths(t *testing.T) {
+	defer runtime.GC() // Exercise finalizers.
+	c := geos.NewContext()
+	g := mustNewGeomFromWKT(t, c, "LINESTRING (0 0, 10 0, 10 10)")
+
+	buffer := g.VariableBufferWithWidths(nil, []float64{1, 3, 0})
+	assert.True(t, buffer.IsValid())
+	assert.True(t, buffer.Contains(mustNewGeomFromWKT(t, c, "POINT (12.5 0)")))
+	assert.False(t, buffer.Contains(mustNewGeomFromWKT(t, c, "POINT (10 10.5)")))
+	assert.True(t, buffer.Intersects(mustNewGeomFromWKT(t, c, "POINT (10 10)")))
+
+	zero := g.VariableBufferWithWidths(nil, []float64{0, 0, -1})
+	assert.Equal(t, geos.TypeIDPolygon, zero.TypeID())
+	assert.True(t, zero.IsEmpty())
+
+	partial := g.VariableBufferWithWidths(nil, []float64{1, 0, 0})
+	assert.Equal(t, geos.TypeIDPolygon, partial.TypeID())
+	assert.False(t, partial.Intersects(mustNewGeomFromWKT(t, c, "POINT (10 5)")))
+
+	assert.Panics(t, func() { g.VariableBufferWithWidths(nil, []float64{1, 2}) })
+}
+
+func TestGeomVariableBufferWithWidthsBufParams(t *testing.T) {
+	defer runtime.GC() // Exercise finalizers.
+	c := geos.NewContext()
+
+	line := mustNewGeomFromWKT(t, c, "LINESTRING (0 0, 10 0)")
+	flat := line.VariableBufferWithWidths(c.NewBufParams().SetEndCapStyle(geos.BufCapStyleFlat), []float64{1, 1})
+	assert.True(t, math.Abs(flat.Area()-20) < 1e-9)
+	assert.Equal(t, geos.NewBox2D(0, -1, 10, 1), flat.Bounds())
+	square := line.VariableBufferWithWidths(c.NewBufParams().SetEndCapStyle(geos.BufCapStyleSquare), []float64{1, 1})
+	assert.True(t, math.Abs(square.Area()-24) < 1e-9)
+	squareBounds := square.Bounds()
+	assert.True(t, math.Abs(squareBounds.MinX+1) < 1e-9)
+	assert.True(t, math.Abs(squareBounds.MaxX-11) < 1e-9)
+
+	corner := mustNewGeomFromWKT(t, c, "LINESTRING (0 0, 10 0, 10 10)")
+	widths := []float64{1, 1, 1}
+	round := corner.VariableBufferWithWidths(c.NewBufParams().SetEndCapStyle(geos.BufCapStyleFlat), widths)
+	assert.False(t, round.Contains(mustNewGeomFromWKT(t, c, "POINT (10.9 -0.9)")))
+	mitre := corner.VariableBufferWithWidths(c.NewBufParams().SetEndCapStyle(geos.BufCapStyleFlat).SetJoinStyle(geos.BufJoinStyleMitre), widths)
+	assert.True(t, mitre.IsValid())
+	assert.True(t, math.Abs(mitre.Area()-40) < 1e-9)
+	assert.True(t, mitre.Contains(mustNewGeomFromWKT(t, c, "POINT (10.9 -0.9)")))
+	bevel := corner.VariableBufferWithWidths(c.NewBufParams().SetEndCapStyle(geos.BufCapStyleFlat).SetJoinStyle(geos.BufJoinStyleBevel), widths)
+	assert.True(t, math.Abs(bevel.Area()-39.5) < 1e-9)
+	assert.False(t, bevel.Contains(mustNewGeomFromWKT(t, c, "POINT (10.9 -0.9)")))
+}
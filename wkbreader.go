@@ -6,6 +6,7 @@ import "C"
 
 import (
 	"runtime"
+	"unsafe"
 )
 
 // A WKBReader reads geometries from WKB (Well Known Binary).
@@ -14,8 +15,22 @@ type WKBReader struct {
 	cWKBReader *C.struct_GEOSWKBReader_t
 }
 
-// NewWKBReader returns a new WKBReader.
-func (c *Context) NewWKBReader() *WKBReader {
+// A WKBReaderOption sets an option on a WKBReader.
+type WKBReaderOption func(*WKBReader)
+
+// WithWKBReaderFixStructure sets whether to fix structural errors in the
+// input, for example by closing unclosed rings. It requires GEOS 3.13 or
+// later.
+func WithWKBReaderFixStructure(fixStructure bool) WKBReaderOption {
+	return func(r *WKBReader) {
+		if C.c_GEOSWKBReader_setFixStructure_r(r.context.cHandle, r.cWKBReader, toInt[C.char](fixStructure)) == 0 {
+			panic(errUnsupportedGEOSVersion)
+		}
+	}
+}
+
+// NewWKBReader returns a new WKBReader with the given options.
+func (c *Context) NewWKBReader(options ...WKBReaderOption) *WKBReader {
 	c.mutex.Lock()
 	defer c.mutex.Unlock()
 	cWKBReader := C.GEOSWKBReader_create_r(c.cHandle)
@@ -25,6 +40,9 @@ func (c *Context) NewWKBReader() *WKBReader {
 	}
 	c.ref()
 	runtime.AddCleanup(wkbReader, c.destroyWKBReader, cWKBReader)
+	for _, option := range options {
+		option(wkbReader)
+	}
 	return wkbReader
 }
 
@@ -40,6 +58,16 @@ func (r *WKBReader) Read(wkb []byte) (*Geom, error) {
 	return r.context.newGeom(C.GEOSWKBReader_read_r(r.context.cHandle, r.cWKBReader, (*C.uchar)(pWkb), C.size_t(len(wkb))), nil), r.context.err
 }
 
+// ReadHex reads a geometry from hex-encoded WKB.
+func (r *WKBReader) ReadHex(hex string) (*Geom, error) {
+	r.context.mutex.Lock()
+	defer r.context.mutex.Unlock()
+	hexCStr := C.CString(hex)
+	defer C.free(unsafe.Pointer(hexCStr))
+	r.context.err = nil
+	return r.context.newGeom(C.GEOSWKBReader_readHEX_r(r.context.cHandle, r.cWKBReader, (*C.uchar)(unsafe.Pointer(hexCStr)), C.size_t(len(hex))), nil), r.context.err
+}
+
 func (c *Context) destroyWKBReader(cWKBReader *C.struct_GEOSWKBReader_t) {
 	c.mutex.Lock()
 	defer c.mutex.Unlock()
@@ -0,0 +1,53 @@
+package geos_test
+
+import (
+	"encoding/binary"
+	"math"
+	"runtime"
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+
+	"github.com/twpayne/go-geos"
+)
+
+func TestWKBReaderReadHex(t *testing.T) {
+	defer runtime.GC() // Exercise finalizers.
+	c := geos.NewContext()
+
+	g, err := c.NewWKBReader().ReadHex("0101000000000000000000F03F0000000000000040")
+	assert.NoError(t, err)
+	assert.Equal(t, "POINT (1 2)", g.ToWKT())
+
+	g, err = c.NewGeomFromHexWKB("0101000000000000000000f03f0000000000000040")
+	assert.NoError(t, err)
+	assert.Equal(t, "POINT (1 2)", g.ToWKT())
+
+	_, err = c.NewGeomFromHexWKB("ZZ")
+	assert.Error(t, err)
+}
+
+func TestWKBReaderFixStructure(t *testing.T) {
+	if geos.VersionCompare(3, 13, 0) < 0 {
+		t.Skip("fix structure requires GEOS 3.13 or later")
+	}
+	defer runtime.GC() // Exercise finalizers.
+	c := geos.NewContext()
+
+	// Construct the WKB of a polygon with an unclosed ring.
+	wkb := []byte{1}
+	wkb = binary.LittleEndian.AppendUint32(wkb, 3) // Polygon.
+	wkb = binary.LittleEndian.AppendUint32(wkb, 1) // Number of rings.
+	wkb = binary.LittleEndian.AppendUint32(wkb, 4) // Number of points.
+	for _, coord := range [][]float64{{0, 0}, {1, 0}, {1, 1}, {0, 1}} {
+		wkb = binary.LittleEndian.AppendUint64(wkb, math.Float64bits(coord[0]))
+		wkb = binary.LittleEndian.AppendUint64(wkb, math.Float64bits(coord[1]))
+	}
+
+	_, err := c.NewGeomFromWKB(wkb)
+	assert.Error(t, err)
+
+	g, err := c.NewGeomFromWKB(wkb, geos.WithWKBReaderFixStructure(true))
+	assert.NoError(t, err)
+	assert.Equal(t, "POLYGON ((0 0, 1 0, 1 1, 0 1, 0 0))", g.ToWKT())
+}
@@ -0,0 +1,297 @@
+package geos
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// A WKBFraming is how WKB records are delimited in a stream.
+type WKBFraming int
+
+// WKB framings.
+const (
+	// WKBFramingConcatenated is a sequence of WKB records with no delimiters.
+	// Record boundaries are found by scanning the structure of each record.
+	WKBFramingConcatenated WKBFraming = iota
+	// WKBFramingLengthPrefixed is a sequence of WKB records, each preceded by
+	// its length in bytes as a little endian uint32.
+	WKBFramingLengthPrefixed
+)
+
+// WKB geometry type flags and codes used when scanning record boundaries.
+const (
+	wkbFlagZ    = 0x80000000
+	wkbFlagM    = 0x40000000
+	wkbFlagSRID = 0x20000000
+
+	wkbTypePoint              = 1
+	wkbTypeLineString         = 2
+	wkbTypePolygon            = 3
+	wkbTypeMultiPoint         = 4
+	wkbTypeMultiLineString    = 5
+	wkbTypeMultiPolygon       = 6
+	wkbTypeGeometryCollection = 7
+	wkbTypeCircularString     = 8
+	wkbTypeCompoundCurve      = 9
+	wkbTypeCurvePolygon       = 10
+	wkbTypeMultiCurve         = 11
+	wkbTypeMultiSurface       = 12
+)
+
+// A WKBDecoder reads a stream of WKB records from an io.Reader.
+type WKBDecoder struct {
+	context   *Context
+	r         *bufio.Reader
+	framing   WKBFraming
+	wkbReader *WKBReader
+	buf       bytes.Buffer
+}
+
+// A WKBDecoderOption sets an option on a WKBDecoder.
+type WKBDecoderOption func(*WKBDecoder)
+
+// WithWKBDecoderFraming sets the framing of records in the stream. The
+// default is WKBFramingConcatenated.
+func WithWKBDecoderFraming(framing WKBFraming) WKBDecoderOption {
+	return func(d *WKBDecoder) {
+		d.framing = framing
+	}
+}
+
+// WithWKBDecoderReaderOptions sets the options of the WKBReader used to decode
+// each record.
+func WithWKBDecoderReaderOptions(options ...WKBReaderOption) WKBDecoderOption {
+	return func(d *WKBDecoder) {
+		d.wkbReader = d.context.NewWKBReader(options...)
+	}
+}
+
+// NewWKBDecoder returns a new WKBDecoder that reads from r.
+func (c *Context) NewWKBDecoder(r io.Reader, options ...WKBDecoderOption) *WKBDecoder {
+	d := &WKBDecoder{
+		context: c,
+		r:       bufio.NewReader(r),
+	}
+	for _, option := range options {
+		option(d)
+	}
+	if d.wkbReader == nil {
+		d.wkbReader = c.NewWKBReader()
+	}
+	return d
+}
+
+// Decode reads the next geometry from the stream. It returns io.EOF when there
+// are no more geometries.
+func (d *WKBDecoder) Decode() (*Geom, error) {
+	d.buf.Reset()
+	var err error
+	switch d.framing {
+	case WKBFramingConcatenated:
+		err = d.scanGeom()
+	case WKBFramingLengthPrefixed:
+		err = d.readLengthPrefixed()
+	default:
+		return nil, fmt.Errorf("%d: %w", d.framing, errInvalidWKBFraming)
+	}
+	switch {
+	case errors.Is(err, io.EOF) && d.buf.Len() == 0:
+		return nil, io.EOF
+	case errors.Is(err, io.EOF):
+		return nil, io.ErrUnexpectedEOF
+	case err != nil:
+		return nil, err
+	}
+	return d.wkbReader.Read(d.buf.Bytes())
+}
+
+// readLengthPrefixed reads a length-prefixed record into d.buf.
+func (d *WKBDecoder) readLengthPrefixed() error {
+	var lengthBuf [4]byte
+	switch n, err := io.ReadFull(d.r, lengthBuf[:]); {
+	case n == 0 && errors.Is(err, io.EOF):
+		return io.EOF
+	case err != nil:
+		return wkbUnexpectedEOF(err)
+	}
+	length := int64(binary.LittleEndian.Uint32(lengthBuf[:]))
+	if _, err := io.CopyN(&d.buf, d.r, length); err != nil {
+		return wkbUnexpectedEOF(err)
+	}
+	return nil
+}
+
+// scanGeom reads a single WKB geometry into d.buf by scanning its structure.
+func (d *WKBDecoder) scanGeom() error {
+	byteOrder, err := d.r.ReadByte()
+	if err != nil {
+		return err
+	}
+	d.buf.WriteByte(byteOrder)
+	var order binary.ByteOrder
+	switch byteOrder {
+	case 0:
+		order = binary.BigEndian
+	case 1:
+		order = binary.LittleEndian
+	default:
+		return fmt.Errorf("%d: %w", byteOrder, errInvalidWKBByteOrder)
+	}
+
+	wkbType, err := d.scanUint32(order)
+	if err != nil {
+		return err
+	}
+	dims := int64(2)
+	if wkbType&wkbFlagZ != 0 {
+		dims++
+	}
+	if wkbType&wkbFlagM != 0 {
+		dims++
+	}
+	if wkbType&wkbFlagSRID != 0 {
+		if _, err := d.scanUint32(order); err != nil {
+			return err
+		}
+	}
+	wkbType &^= wkbFlagZ | wkbFlagM | wkbFlagSRID
+	switch wkbType / 1000 {
+	case 1, 2:
+		dims++
+	case 3:
+		dims += 2
+	}
+
+	switch wkbType % 1000 {
+	case wkbTypePoint:
+		return d.scanBytes(8 * dims)
+	case wkbTypeLineString, wkbTypeCircularString:
+		return d.scanPoints(order, dims)
+	case wkbTypePolygon:
+		numRings, err := d.scanUint32(order)
+		if err != nil {
+			return err
+		}
+		for range numRings {
+			if err := d.scanPoints(order, dims); err != nil {
+				return err
+			}
+		}
+		return nil
+	case wkbTypeMultiPoint, wkbTypeMultiLineString, wkbTypeMultiPolygon, wkbTypeGeometryCollection,
+		wkbTypeCompoundCurve, wkbTypeCurvePolygon, wkbTypeMultiCurve, wkbTypeMultiSurface:
+		numGeoms, err := d.scanUint32(order)
+		if err != nil {
+			return err
+		}
+		for range numGeoms {
+			switch err := d.scanGeom(); {
+			case errors.Is(err, io.EOF):
+				return io.ErrUnexpectedEOF
+			case err != nil:
+				return err
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("%d: %w", wkbType, errUnsupportedTypeID)
+	}
+}
+
+// scanBytes copies n bytes into d.buf.
+func (d *WKBDecoder) scanBytes(n int64) error {
+	if _, err := io.CopyN(&d.buf, d.r, n); err != nil {
+		return wkbUnexpectedEOF(err)
+	}
+	return nil
+}
+
+// scanPoints copies a point count and its points into d.buf.
+func (d *WKBDecoder) scanPoints(order binary.ByteOrder, dims int64) error {
+	numPoints, err := d.scanUint32(order)
+	if err != nil {
+		return err
+	}
+	return d.scanBytes(8 * dims * int64(numPoints))
+}
+
+// scanUint32 copies a uint32 into d.buf and returns its value.
+func (d *WKBDecoder) scanUint32(order binary.ByteOrder) (uint32, error) {
+	var b [4]byte
+	if _, err := io.ReadFull(d.r, b[:]); err != nil {
+		return 0, wkbUnexpectedEOF(err)
+	}
+	d.buf.Write(b[:])
+	return order.Uint32(b[:]), nil
+}
+
+// wkbUnexpectedEOF returns io.ErrUnexpectedEOF if err is an EOF in the middle
+// of a record, otherwise it returns err.
+func wkbUnexpectedEOF(err error) error {
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
+
+// A WKBEncoder writes a stream of WKB records to an io.Writer.
+type WKBEncoder struct {
+	context   *Context
+	w         io.Writer
+	framing   WKBFraming
+	wkbWriter *WKBWriter
+}
+
+// A WKBEncoderOption sets an option on a WKBEncoder.
+type WKBEncoderOption func(*WKBEncoder)
+
+// WithWKBEncoderFraming sets the framing of records in the stream. The
+// default is WKBFramingConcatenated.
+func WithWKBEncoderFraming(framing WKBFraming) WKBEncoderOption {
+	return func(e *WKBEncoder) {
+		e.framing = framing
+	}
+}
+
+// WithWKBEncoderWriterOptions sets the options of the WKBWriter used to
+// encode each record.
+func WithWKBEncoderWriterOptions(options ...WKBWriterOption) WKBEncoderOption {
+	return func(e *WKBEncoder) {
+		e.wkbWriter = e.context.NewWKBWriter(options...)
+	}
+}
+
+// NewWKBEncoder returns a new WKBEncoder that writes to w.
+func (c *Context) NewWKBEncoder(w io.Writer, options ...WKBEncoderOption) *WKBEncoder {
+	e := &WKBEncoder{
+		context: c,
+		w:       w,
+	}
+	for _, option := range options {
+		option(e)
+	}
+	if e.wkbWriter == nil {
+		e.wkbWriter = c.NewWKBWriter()
+	}
+	return e
+}
+
+// Encode writes g to the stream.
+func (e *WKBEncoder) Encode(g *Geom) error {
+	wkb := e.wkbWriter.Write(g)
+	switch e.framing {
+	case WKBFramingConcatenated:
+	case WKBFramingLengthPrefixed:
+		if _, err := e.w.Write(binary.LittleEndian.AppendUint32(nil, uint32(len(wkb)))); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("%d: %w", e.framing, errInvalidWKBFraming)
+	}
+	_, err := e.w.Write(wkb)
+	return err
+}
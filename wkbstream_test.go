@@ -0,0 +1,132 @@
+package geos_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"runtime"
+	"testing"
+	"testing/iotest"
+
+	"github.com/alecthomas/assert/v2"
+
+	"github.com/twpayne/go-geos"
+)
+
+func TestWKBStreamRoundTrip(t *testing.T) {
+	defer runtime.GC() // Exercise finalizers.
+	c := geos.NewContext()
+	geoms := []*geos.Geom{
+		mustNewGeomFromWKT(t, c, "POINT (1 2)"),
+		mustNewGeomFromWKT(t, c, "POINT Z (1 2 3)"),
+		mustNewGeomFromWKT(t, c, "LINESTRING (0 0, 1 1, 2 0)"),
+		mustNewGeomFromWKT(t, c, "POLYGON ((0 0, 4 0, 4 4, 0 4, 0 0), (1 1, 2 1, 2 2, 1 2, 1 1))"),
+		mustNewGeomFromWKT(t, c, "MULTIPOINT ((0 0), (1 1))"),
+		mustNewGeomFromWKT(t, c, "MULTIPOLYGON Z (((0 0 1, 1 0 1, 1 1 1, 0 0 1)))"),
+		mustNewGeomFromWKT(t, c, "GEOMETRYCOLLECTION (POINT (1 2), LINESTRING (0 0, 1 1), GEOMETRYCOLLECTION (POINT (3 4)))"),
+		mustNewGeomFromWKT(t, c, "LINESTRING EMPTY"),
+		mustNewGeomFromWKT(t, c, "POINT (5 6)").SetSRID(4326),
+	}
+	for _, tc := range []struct {
+		name           string
+		encoderOptions []geos.WKBEncoderOption
+		decoderOptions []geos.WKBDecoderOption
+	}{
+		{
+			name: "concatenated",
+		},
+		{
+			name: "concatenated_big_endian_ewkb",
+			encoderOptions: []geos.WKBEncoderOption{
+				geos.WithWKBEncoderWriterOptions(
+					geos.WithWKBWriterByteOrder(geos.WKBByteOrderBigEndian),
+					geos.WithWKBWriterIncludeSRID(true),
+				),
+			},
+		},
+		{
+			name: "concatenated_iso",
+			encoderOptions: []geos.WKBEncoderOption{
+				geos.WithWKBEncoderWriterOptions(
+					geos.WithWKBWriterFlavor(geos.WKBFlavorISO),
+				),
+			},
+		},
+		{
+			name: "length_prefixed",
+			encoderOptions: []geos.WKBEncoderOption{
+				geos.WithWKBEncoderFraming(geos.WKBFramingLengthPrefixed),
+			},
+			decoderOptions: []geos.WKBDecoderOption{
+				geos.WithWKBDecoderFraming(geos.WKBFramingLengthPrefixed),
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			encoder := c.NewWKBEncoder(&buf, tc.encoderOptions...)
+			for _, g := range geoms {
+				assert.NoError(t, encoder.Encode(g))
+			}
+
+			decoder := c.NewWKBDecoder(&buf, tc.decoderOptions...)
+			for _, expected := range geoms {
+				actual, err := decoder.Decode()
+				assert.NoError(t, err)
+				assert.True(t, expected.EqualsIdentical(actual))
+			}
+			_, err := decoder.Decode()
+			assert.Equal(t, io.EOF, err)
+		})
+	}
+}
+
+func TestWKBStreamTruncated(t *testing.T) {
+	defer runtime.GC() // Exercise finalizers.
+	c := geos.NewContext()
+	g := mustNewGeomFromWKT(t, c, "LINESTRING (0 0, 1 1, 2 0)")
+	for _, framing := range []geos.WKBFraming{
+		geos.WKBFramingConcatenated,
+		geos.WKBFramingLengthPrefixed,
+	} {
+		var buf bytes.Buffer
+		encoder := c.NewWKBEncoder(&buf, geos.WithWKBEncoderFraming(framing))
+		assert.NoError(t, encoder.Encode(g))
+		assert.NoError(t, encoder.Encode(g))
+		buf.Truncate(buf.Len() - 1)
+
+		decoder := c.NewWKBDecoder(&buf, geos.WithWKBDecoderFraming(framing))
+		actual, err := decoder.Decode()
+		assert.NoError(t, err)
+		assert.True(t, g.EqualsIdentical(actual))
+		_, err = decoder.Decode()
+		assert.Equal(t, io.ErrUnexpectedEOF, err)
+	}
+}
+
+func TestWKBStreamReadError(t *testing.T) {
+	defer runtime.GC() // Exercise finalizers.
+	c := geos.NewContext()
+	g := mustNewGeomFromWKT(t, c, "LINESTRING (0 0, 1 1, 2 0)")
+	errRead := errors.New("read error")
+	for _, framing := range []geos.WKBFraming{
+		geos.WKBFramingConcatenated,
+		geos.WKBFramingLengthPrefixed,
+	} {
+		var buf bytes.Buffer
+		encoder := c.NewWKBEncoder(&buf, geos.WithWKBEncoderFraming(framing))
+		assert.NoError(t, encoder.Encode(g))
+		buf.Truncate(buf.Len() - 1)
+
+		decoder := c.NewWKBDecoder(io.MultiReader(&buf, iotest.ErrReader(errRead)), geos.WithWKBDecoderFraming(framing))
+		_, err := decoder.Decode()
+		assert.IsError(t, err, errRead)
+	}
+}
+
+func TestWKBDecoderInvalidByteOrder(t *testing.T) {
+	c := geos.NewContext()
+	decoder := c.NewWKBDecoder(bytes.NewReader([]byte{2, 1, 0, 0, 0}))
+	_, err := decoder.Decode()
+	assert.Error(t, err)
+}
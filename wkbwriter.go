@@ -17,6 +17,15 @@ const (
 	WKBFlavorISO      WKBFlavor = C.GEOS_WKB_ISO
 )
 
+// A WKBByteOrder is a WKB byte order.
+type WKBByteOrder int
+
+// WKB byte orders.
+const (
+	WKBByteOrderBigEndian    WKBByteOrder = C.GEOS_WKB_XDR
+	WKBByteOrderLittleEndian WKBByteOrder = C.GEOS_WKB_NDR
+)
+
 // A WKBWriter writes geometries as WKB (Well Known Binary).
 type WKBWriter struct {
 	context    *Context
@@ -26,6 +35,13 @@ type WKBWriter struct {
 // A WKBWriterOption sets an option on a WKBWriter.
 type WKBWriterOption func(*WKBWriter)
 
+// WithWKBWriterByteOrder sets the byte order.
+func WithWKBWriterByteOrder(byteOrder WKBByteOrder) WKBWriterOption {
+	return func(w *WKBWriter) {
+		C.GEOSWKBWriter_setByteOrder_r(w.context.cHandle, w.cWKBWriter, C.int(byteOrder))
+	}
+}
+
 // WithWKBWriterFlavor sets the WKB flavor.
 func WithWKBWriterFlavor(flavor WKBFlavor) WKBWriterOption {
 	return func(w *WKBWriter) {
@@ -40,6 +56,14 @@ func WithWKBWriterIncludeSRID(includeSRID bool) WKBWriterOption {
 	}
 }
 
+// WithWKBWriterOutputDimension sets the maximum number of dimensions to
+// write, which must be between 2 and 4.
+func WithWKBWriterOutputDimension(outputDimension int) WKBWriterOption {
+	return func(w *WKBWriter) {
+		C.GEOSWKBWriter_setOutputDimension_r(w.context.cHandle, w.cWKBWriter, C.int(outputDimension))
+	}
+}
+
 // NewWKBWriter returns a new WKBWriter with the given options.
 func (c *Context) NewWKBWriter(options ...WKBWriterOption) *WKBWriter {
 	c.mutex.Lock()
@@ -67,6 +91,17 @@ func (w *WKBWriter) Write(g *Geom) []byte {
 	return C.GoBytes(unsafe.Pointer(cWKBBuf), C.int(size))
 }
 
+// WriteHex returns the WKB representation of g as an uppercase hexadecimal
+// string.
+func (w *WKBWriter) WriteHex(g *Geom) string {
+	w.context.mutex.Lock()
+	defer w.context.mutex.Unlock()
+	var size C.size_t
+	cWKBHexBuf := C.GEOSWKBWriter_writeHEX_r(g.context.cHandle, w.cWKBWriter, g.cGeom, &size)
+	defer C.GEOSFree_r(g.context.cHandle, unsafe.Pointer(cWKBHexBuf))
+	return C.GoStringN((*C.char)(unsafe.Pointer(cWKBHexBuf)), C.int(size))
+}
+
 func (c *Context) destroyWKBWriter(cWKBWriter *C.struct_GEOSWKBWriter_t) {
 	c.mutex.Lock()
 	defer c.mutex.Unlock()
@@ -0,0 +1,61 @@
+package geos_test
+
+import (
+	"encoding/hex"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+
+	"github.com/twpayne/go-geos"
+)
+
+func TestWKBWriter(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		options     []geos.WKBWriterOption
+		wkt         string
+		expectedHex string
+	}{
+		{
+			name:        "default",
+			wkt:         "POINT (1 2)",
+			expectedHex: "0101000000000000000000F03F0000000000000040",
+		},
+		{
+			name:        "little_endian",
+			options:     []geos.WKBWriterOption{geos.WithWKBWriterByteOrder(geos.WKBByteOrderLittleEndian)},
+			wkt:         "POINT (1 2)",
+			expectedHex: "0101000000000000000000F03F0000000000000040",
+		},
+		{
+			name:        "big_endian",
+			options:     []geos.WKBWriterOption{geos.WithWKBWriterByteOrder(geos.WKBByteOrderBigEndian)},
+			wkt:         "POINT (1 2)",
+			expectedHex: "00000000013FF00000000000004000000000000000",
+		},
+		{
+			name:        "output_dimension",
+			options:     []geos.WKBWriterOption{geos.WithWKBWriterOutputDimension(2)},
+			wkt:         "POINT Z (1 2 3)",
+			expectedHex: "0101000000000000000000F03F0000000000000040",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			defer runtime.GC() // Exercise finalizers.
+			c := geos.NewContext()
+			g := mustNewGeomFromWKT(t, c, tc.wkt)
+			w := c.NewWKBWriter(tc.options...)
+			assert.Equal(t, tc.expectedHex, w.WriteHex(g))
+			assert.Equal(t, tc.expectedHex, strings.ToUpper(hex.EncodeToString(w.Write(g))))
+		})
+	}
+}
+
+func TestGeomToEWKBHexWithSRID(t *testing.T) {
+	defer runtime.GC() // Exercise finalizers.
+	c := geos.NewContext()
+	g := mustNewGeomFromWKT(t, c, "POINT (1 2)").SetSRID(4326)
+	assert.Equal(t, "0101000020E6100000000000000000F03F0000000000000040", g.ToEWKBHexWithSRID())
+}
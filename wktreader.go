@@ -15,8 +15,22 @@ type WKTReader struct {
 	cWKTReader *C.struct_GEOSWKTReader_t
 }
 
-// NewWKTReader returns a new WKTReader.
-func (c *Context) NewWKTReader() *WKTReader {
+// A WKTReaderOption sets an option on a WKTReader.
+type WKTReaderOption func(*WKTReader)
+
+// WithWKTReaderFixStructure sets whether to fix structural errors in the
+// input, for example by closing unclosed rings. It requires GEOS 3.13 or
+// later.
+func WithWKTReaderFixStructure(fixStructure bool) WKTReaderOption {
+	return func(r *WKTReader) {
+		if C.c_GEOSWKTReader_setFixStructure_r(r.context.cHandle, r.cWKTReader, toInt[C.char](fixStructure)) == 0 {
+			panic(errUnsupportedGEOSVersion)
+		}
+	}
+}
+
+// NewWKTReader returns a new WKTReader with the given options.
+func (c *Context) NewWKTReader(options ...WKTReaderOption) *WKTReader {
 	c.mutex.Lock()
 	defer c.mutex.Unlock()
 	cWKTReader := C.GEOSWKTReader_create_r(c.cHandle)
@@ -26,6 +40,9 @@ func (c *Context) NewWKTReader() *WKTReader {
 	}
 	c.ref()
 	runtime.AddCleanup(wktReader, c.destroyWKTReader, cWKTReader)
+	for _, option := range options {
+		option(wktReader)
+	}
 	return wktReader
 }
 
@@ -0,0 +1,30 @@
+package geos_test
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+
+	"github.com/twpayne/go-geos"
+)
+
+func TestWKTReaderFixStructure(t *testing.T) {
+	if geos.VersionCompare(3, 13, 0) < 0 {
+		t.Skip("fix structure requires GEOS 3.13 or later")
+	}
+	defer runtime.GC() // Exercise finalizers.
+	c := geos.NewContext()
+	const unclosedWKT = "POLYGON ((0 0, 1 0, 1 1, 0 1))"
+
+	_, err := c.NewGeomFromWKT(unclosedWKT)
+	assert.Error(t, err)
+
+	g, err := c.NewGeomFromWKT(unclosedWKT, geos.WithWKTReaderFixStructure(true))
+	assert.NoError(t, err)
+	assert.Equal(t, "POLYGON ((0 0, 1 0, 1 1, 0 1, 0 0))", g.ToWKT())
+
+	g, err = c.NewWKTReader(geos.WithWKTReaderFixStructure(true)).Read(unclosedWKT)
+	assert.NoError(t, err)
+	assert.Equal(t, "POLYGON ((0 0, 1 0, 1 1, 0 1, 0 0))", g.ToWKT())
+}
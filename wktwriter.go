@@ -14,8 +14,43 @@ type WKTWriter struct {
 	cWKTWriter *C.struct_GEOSWKTWriter_t
 }
 
-// NewWKTWriter returns a new WKTWriter.
-func (c *Context) NewWKTWriter() *WKTWriter {
+// A WKTWriterOption sets an option on a WKTWriter.
+type WKTWriterOption func(*WKTWriter)
+
+// WithWKTWriterOld3D sets whether to write 3D geometries in the old style,
+// e.g. POINT (1 2 3) instead of POINT Z (1 2 3).
+func WithWKTWriterOld3D(old3D bool) WKTWriterOption {
+	return func(w *WKTWriter) {
+		C.GEOSWKTWriter_setOld3D_r(w.context.cHandle, w.cWKTWriter, toInt[C.int](old3D))
+	}
+}
+
+// WithWKTWriterOutputDimension sets the maximum number of dimensions to
+// write, which must be between 2 and 4.
+func WithWKTWriterOutputDimension(outputDimension int) WKTWriterOption {
+	return func(w *WKTWriter) {
+		C.GEOSWKTWriter_setOutputDimension_r(w.context.cHandle, w.cWKTWriter, C.int(outputDimension))
+	}
+}
+
+// WithWKTWriterRoundingPrecision sets the number of decimal places to write.
+// A negative value means full precision.
+func WithWKTWriterRoundingPrecision(roundingPrecision int) WKTWriterOption {
+	return func(w *WKTWriter) {
+		C.GEOSWKTWriter_setRoundingPrecision_r(w.context.cHandle, w.cWKTWriter, C.int(roundingPrecision))
+	}
+}
+
+// WithWKTWriterTrim sets whether to trim unnecessary trailing zeros from
+// numbers.
+func WithWKTWriterTrim(trim bool) WKTWriterOption {
+	return func(w *WKTWriter) {
+		C.GEOSWKTWriter_setTrim_r(w.context.cHandle, w.cWKTWriter, toInt[C.char](trim))
+	}
+}
+
+// NewWKTWriter returns a new WKTWriter with the given options.
+func (c *Context) NewWKTWriter(options ...WKTWriterOption) *WKTWriter {
 	c.mutex.Lock()
 	defer c.mutex.Unlock()
 	cWKTWriter := C.GEOSWKTWriter_create_r(c.cHandle)
@@ -25,6 +60,9 @@ func (c *Context) NewWKTWriter() *WKTWriter {
 	}
 	c.ref()
 	runtime.AddCleanup(wktWriter, c.destroyWKTWriter, cWKTWriter)
+	for _, option := range options {
+		option(wktWriter)
+	}
 	return wktWriter
 }
 
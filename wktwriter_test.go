@@ -0,0 +1,62 @@
+package geos_test
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+
+	"github.com/twpayne/go-geos"
+)
+
+func TestWKTWriter(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		options  []geos.WKTWriterOption
+		wkt      string
+		expected string
+	}{
+		{
+			name:     "default",
+			wkt:      "POINT Z (1 2 3)",
+			expected: "POINT Z (1 2 3)",
+		},
+		{
+			name:     "rounding_precision",
+			options:  []geos.WKTWriterOption{geos.WithWKTWriterRoundingPrecision(2)},
+			wkt:      "POINT (1.23456 4.56789)",
+			expected: "POINT (1.23 4.57)",
+		},
+		{
+			name: "rounding_precision_no_trim",
+			options: []geos.WKTWriterOption{
+				geos.WithWKTWriterRoundingPrecision(2),
+				geos.WithWKTWriterTrim(false),
+			},
+			wkt:      "POINT (1 2)",
+			expected: "POINT (1.00 2.00)",
+		},
+		{
+			name:     "output_dimension",
+			options:  []geos.WKTWriterOption{geos.WithWKTWriterOutputDimension(2)},
+			wkt:      "POINT Z (1 2 3)",
+			expected: "POINT (1 2)",
+		},
+		{
+			name:     "old_3d",
+			options:  []geos.WKTWriterOption{geos.WithWKTWriterOld3D(true)},
+			wkt:      "POINT Z (1 2 3)",
+			expected: "POINT (1 2 3)",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			defer runtime.GC() // Exercise finalizers.
+			c := geos.NewContext()
+			g := mustNewGeomFromWKT(t, c, tc.wkt)
+			assert.Equal(t, tc.expected, c.NewWKTWriter(tc.options...).Write(g))
+
+			c2 := geos.NewContext(geos.WithContextWKTWriterOptions(tc.options...))
+			assert.Equal(t, tc.expected, mustNewGeomFromWKT(t, c2, tc.wkt).ToWKT())
+		})
+	}
+}